@@ -0,0 +1,172 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// noVersion version of a node not existing before or after the write
+const noVersion int32 = -1
+
+// AuditRecord record of a write performed through the client
+type AuditRecord struct {
+	Time  time.Time `json:"time"`
+	Actor string    `json:"actor,omitempty"`
+	Op    string    `json:"op"`
+	Path  string    `json:"path"`
+	// OldVersion version replaced by the write, -1 if the node not existed or any version matched
+	OldVersion int32 `json:"old_version"`
+	// NewVersion version after the write, -1 if the node deleted or the write failed
+	NewVersion int32 `json:"new_version"`
+	Size       int   `json:"size"`
+	// Digest sha256 of the payload in hex
+	Digest string `json:"digest,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AuditSink receive audit records of writes, see WithAuditSink.
+// The method is called synchronously after each write, and should return quickly.
+type AuditSink interface {
+	Audit(record *AuditRecord) error
+}
+
+// replacedVersion the version replaced by a set of any version, which increases the version by one
+func replacedVersion(newVersion int32) int32 {
+	if newVersion <= 0 {
+		return noVersion
+	}
+
+	return newVersion - 1
+}
+
+type actorKey struct{}
+
+// ContextWithActor return a ctx carrying the actor recorded in audit records
+// of writes by the Context suffixed APIs, e.g. SetRawValueContext.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext return the actor carried by the ctx
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// auditing whether audit enabled
+func (cli *Client) auditing() bool {
+	return cli.auditSink != nil
+}
+
+// audit the write of the path to the sink
+func (cli *Client) audit(ctx context.Context, op, path string, oldVersion, newVersion int32, data []byte, err error) {
+	if cli.auditSink == nil {
+		return
+	}
+
+	r := &AuditRecord{
+		Time:       time.Now(),
+		Actor:      ActorFromContext(ctx),
+		Op:         op,
+		Path:       path,
+		OldVersion: oldVersion,
+		NewVersion: newVersion,
+		Size:       len(data),
+	}
+
+	if r.Actor == "" {
+		r.Actor = cli.auditActor
+	}
+
+	if data != nil {
+		sum := sha256.Sum256(data)
+		r.Digest = hex.EncodeToString(sum[:])
+	}
+
+	if err != nil {
+		r.NewVersion = noVersion
+		r.Error = err.Error()
+	}
+
+	if auditErr := cli.auditSink.Audit(r); auditErr != nil {
+		cli.log().Error("zk audit error", "path", path, "op", op, "error", auditErr)
+	}
+}
+
+// auditOps audit each operation of the transaction, all operations failed with the err if not nil
+func (cli *Client) auditOps(ctx context.Context, ops []interface{}, responses []zk.MultiResponse, err error) {
+	if cli.auditSink == nil {
+		return
+	}
+
+	for i, op := range ops {
+		var res zk.MultiResponse
+		if err == nil && i < len(responses) {
+			res = responses[i]
+		}
+
+		switch req := op.(type) {
+		case *zk.CreateRequest:
+			path := req.Path
+			if res.String != "" {
+				path = res.String
+			}
+
+			cli.audit(ctx, OpCreate, path, noVersion, 0, req.Data, err)
+		case *zk.SetDataRequest:
+			newVersion := noVersion
+			if res.Stat != nil {
+				newVersion = res.Stat.Version
+			}
+
+			cli.audit(ctx, OpSet, req.Path, req.Version, newVersion, req.Data, err)
+		case *zk.DeleteRequest:
+			cli.audit(ctx, OpDelete, req.Path, req.Version, noVersion, nil, err)
+		}
+	}
+}
+
+// FileAuditSink write audit records to a file in json lines
+type FileAuditSink struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewFileAuditSink open the file in append mode to write audit records
+func NewFileAuditSink(filename string) (*FileAuditSink, error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAuditSink{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+// Audit implements AuditSink
+func (s *FileAuditSink) Audit(record *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.encoder.Encode(record)
+}
+
+// Close the file
+func (s *FileAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
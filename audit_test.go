@@ -0,0 +1,157 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+type auditRecorder struct {
+	records []*AuditRecord
+}
+
+func (r *auditRecorder) Audit(record *AuditRecord) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func TestFileAuditSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zkaudit")
+	assert.Nil(t, err)
+
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "audit.jsonl")
+
+	sink, err := NewFileAuditSink(filename)
+	assert.Nil(t, err)
+
+	cli := &Client{}
+	cli.auditSink = sink
+	cli.auditActor = "service"
+
+	cli.audit(ContextWithActor(context.Background(), "alice"), OpSet, "/a", 1, 2, []byte("abc"), nil)
+	cli.audit(context.Background(), OpDelete, "/b", 3, noVersion, nil, zk.ErrNotEmpty)
+	assert.Nil(t, sink.Close())
+
+	file, err := os.Open(filename)
+	assert.Nil(t, err)
+
+	defer file.Close()
+
+	var records []*AuditRecord
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		r := &AuditRecord{}
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), r))
+		records = append(records, r)
+	}
+
+	assert.Len(t, records, 2)
+	assert.Equal(t, "alice", records[0].Actor)
+	assert.Equal(t, OpSet, records[0].Op)
+	assert.Equal(t, int32(1), records[0].OldVersion)
+	assert.Equal(t, int32(2), records[0].NewVersion)
+	assert.Equal(t, 3, records[0].Size)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", records[0].Digest)
+	assert.Equal(t, "service", records[1].Actor)
+	assert.Equal(t, noVersion, records[1].NewVersion)
+	assert.Equal(t, zk.ErrNotEmpty.Error(), records[1].Error)
+	assert.Empty(t, records[1].Digest)
+}
+
+func TestAudit(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/audit"
+	_ = testClient.DeleteRecursive(path)
+
+	r := &auditRecorder{}
+	testClient.auditSink = r
+
+	defer func() { testClient.auditSink = nil }()
+
+	ctx := ContextWithActor(context.Background(), "alice")
+
+	assert.Nil(t, testClient.EnsurePathContext(ctx, path))
+	assert.Nil(t, testClient.EnsurePathContext(ctx, path))
+	assert.Nil(t, testClient.SetRawValueContext(ctx, path+"/a", []byte("1")))
+	assert.Nil(t, testClient.SetRawValueVersionContext(ctx, path+"/a", []byte("2"), 1))
+	assert.Equal(t, zk.ErrBadVersion, testClient.SetRawValueVersionContext(ctx, path+"/a", []byte("3"), 1))
+	assert.Nil(t, testClient.SetTempRawValueContext(ctx, path+"/t", []byte("1")))
+	assert.Nil(t, testClient.SetTempRawValueContext(ctx, path+"/t", []byte("2")))
+	assert.Nil(t, testClient.DeleteContext(ctx, path+"/a"))
+	assert.Nil(t, testClient.DeleteContext(ctx, path+"/a"))
+
+	var records []string
+
+	for _, record := range r.records {
+		assert.Equal(t, "alice", record.Actor)
+		records = append(records, record.Op+" "+record.Path+" "+
+			string(rune('0'+record.OldVersion+1))+string(rune('0'+record.NewVersion+1))+" "+record.Error)
+	}
+
+	// versions are shifted by one, e.g. 01 means -1 to 0
+	assert.Equal(t, []string{
+		"ensure_path /test/audit 01 ",
+		"set /test/audit/a 12 ",
+		"set /test/audit/a 23 ",
+		"set /test/audit/a 20 " + zk.ErrBadVersion.Error(),
+		"create /test/audit/t 01 ",
+		"set /test/audit/t 12 ",
+		"delete /test/audit/a 30 ",
+	}, records)
+
+	_ = testClient.DeleteRecursive(path)
+}
+
+func TestAuditOps(t *testing.T) {
+	r := &auditRecorder{}
+	cli := &Client{}
+	cli.auditSink = r
+
+	ops := []interface{}{
+		&zk.CreateRequest{Path: "/q/item-", Data: []byte("1")},
+		&zk.SetDataRequest{Path: "/q", Data: []byte("2"), Version: 2},
+		&zk.DeleteRequest{Path: "/q/old", Version: -1},
+	}
+
+	cli.auditOps(context.Background(), ops, []zk.MultiResponse{
+		{String: "/q/item-0000000001"},
+		{Stat: &zk.Stat{Version: 3}},
+		{},
+	}, nil)
+	cli.auditOps(context.Background(), ops, nil, zk.ErrBadVersion)
+
+	var records []string
+
+	for _, record := range r.records {
+		records = append(records, record.Op+" "+record.Path+" "+
+			string(rune('0'+record.OldVersion+1))+string(rune('0'+record.NewVersion+1))+" "+record.Error)
+	}
+
+	// versions are shifted by one, e.g. 01 means -1 to 0
+	assert.Equal(t, []string{
+		"create /q/item-0000000001 01 ",
+		"set /q 34 ",
+		"delete /q/old 00 ",
+		"create /q/item- 00 " + zk.ErrBadVersion.Error(),
+		"set /q 30 " + zk.ErrBadVersion.Error(),
+		"delete /q/old 00 " + zk.ErrBadVersion.Error(),
+	}, records)
+}
@@ -0,0 +1,219 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	barrierReadyNode    = "ready"
+	barrierMemberPrefix = "member-"
+)
+
+// Barrier distributed barrier, Wait blocks until the barrier node is removed
+type Barrier struct {
+	client *Client
+	path   string
+}
+
+// NewBarrier create barrier of the path
+func (cli *Client) NewBarrier(path string) *Barrier {
+	return &Barrier{client: cli, path: path}
+}
+
+// Set the barrier
+func (b *Barrier) Set() error {
+	return b.client.EnsurePath(b.path)
+}
+
+// Remove the barrier, release all waiters
+func (b *Barrier) Remove() error {
+	return b.client.Delete(b.path)
+}
+
+// Wait until the barrier is removed or the ctx is done
+func (b *Barrier) Wait(ctx context.Context) error {
+	for {
+		exists, _, ch, err := b.client.Conn().ExistsW(b.path)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return nil
+		}
+
+		if err := b.client.waitEvent(ctx, ch); err != nil {
+			return err
+		}
+	}
+}
+
+// DoubleBarrier distributed double barrier,
+// members enter together when the member count reached, and leave together after all members left.
+type DoubleBarrier struct {
+	client      *Client
+	path        string
+	readyPath   string
+	memberCount int
+	node        string
+}
+
+// NewDoubleBarrier create double barrier of the path for the member count
+func (cli *Client) NewDoubleBarrier(path string, memberCount int) (*DoubleBarrier, error) {
+	if memberCount <= 0 {
+		return nil, errors.New("member count should be positive")
+	}
+
+	return &DoubleBarrier{
+		client:      cli,
+		path:        path,
+		readyPath:   PathJoin(path, barrierReadyNode),
+		memberCount: memberCount,
+	}, nil
+}
+
+// Enter the barrier, block until the member count reached or the ctx is done
+func (b *DoubleBarrier) Enter(ctx context.Context) error {
+	if b.node != "" {
+		return errors.New("already entered")
+	}
+
+	if err := b.client.EnsurePathContext(ctx, b.path); err != nil {
+		return err
+	}
+
+	// watch the ready node before joining, so that the creation won't be missed
+	exists, _, readyCh, err := b.client.Conn().ExistsW(b.readyPath)
+	if err != nil {
+		return err
+	}
+
+	nodePath, err := b.client.CreateContext(ctx, PathJoin(b.path, barrierMemberPrefix), nil, zk.FlagEphemeral|zk.FlagSequence)
+	if err != nil {
+		return err
+	}
+
+	b.node = nodePath[len(b.path)+1:]
+
+	if exists {
+		return nil
+	}
+
+	members, err := b.members()
+	if err != nil {
+		return b.abort(err)
+	}
+
+	if len(members) >= b.memberCount {
+		if _, err = b.client.CreateContext(ctx, b.readyPath, nil, 0); err != nil && err != zk.ErrNodeExists {
+			return b.abort(err)
+		}
+
+		return nil
+	}
+
+	if err = b.client.waitEvent(ctx, readyCh); err != nil {
+		return b.abort(err)
+	}
+
+	return nil
+}
+
+// Leave the barrier, block until all members left or the ctx is done
+func (b *DoubleBarrier) Leave(ctx context.Context) error {
+	if b.node == "" {
+		return errors.New("not entered")
+	}
+
+	for {
+		members, err := b.members()
+		if err != nil {
+			return err
+		}
+
+		joined := containsNode(members, b.node)
+
+		if len(members) == 0 || (joined && len(members) == 1) {
+			b.cleanup()
+			return nil
+		}
+
+		sortSequenceNodes(members)
+
+		// the lowest member waits for the highest one, others delete themselves and wait for the lowest one
+		watch := members[0]
+
+		if watch == b.node {
+			watch = members[len(members)-1]
+		} else if joined {
+			if err = b.client.DeleteContext(ctx, PathJoin(b.path, b.node)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		exists, _, ch, err := b.client.Conn().ExistsW(PathJoin(b.path, watch))
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			continue
+		}
+
+		if err = b.client.waitEvent(ctx, ch); err != nil {
+			return err
+		}
+	}
+}
+
+// members return member nodes of the barrier
+func (b *DoubleBarrier) members() ([]string, error) {
+	children, _, err := b.client.Conn().Children(b.path)
+	if err != nil {
+		return nil, err
+	}
+
+	members := children[:0]
+
+	for _, child := range children {
+		if child != barrierReadyNode {
+			members = append(members, child)
+		}
+	}
+
+	return members, nil
+}
+
+// abort delete the member node and return the error
+func (b *DoubleBarrier) abort(err error) error {
+	if delErr := b.client.Delete(PathJoin(b.path, b.node)); delErr != nil {
+		b.client.log().Warn("zk failed to delete barrier member", "path", b.path, "member", b.node, "error", delErr)
+	}
+
+	b.node = ""
+
+	return err
+}
+
+// cleanup delete the member node and the ready node after all members left
+func (b *DoubleBarrier) cleanup() {
+	if err := b.client.Delete(PathJoin(b.path, b.node)); err != nil {
+		b.client.log().Warn("zk failed to delete barrier member", "path", b.path, "member", b.node, "error", err)
+	}
+
+	if err := b.client.Delete(b.readyPath); err != nil {
+		b.client.log().Warn("zk failed to delete barrier ready node", "path", b.readyPath, "error", err)
+	}
+
+	b.node = ""
+}
@@ -0,0 +1,64 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBarrier(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	b := testClient.NewBarrier("/test/barrier")
+	assert.Nil(t, b.Set())
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, b.Wait(timeoutCtx))
+
+	go func() {
+		waitEventWatch()
+		_ = b.Remove()
+	}()
+
+	assert.Nil(t, b.Wait(context.Background()))
+}
+
+func TestDoubleBarrier(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	const members = 3
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < members; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+			defer cancel()
+
+			b, err := testClient.NewDoubleBarrier("/test/double_barrier", members)
+			assert.Nil(t, err)
+			assert.Nil(t, b.Enter(ctx))
+			assert.Nil(t, b.Leave(ctx))
+		}()
+	}
+
+	wg.Wait()
+}
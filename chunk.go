@@ -0,0 +1,321 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	// chunkManifestMagic prefix of the manifest data stored in a chunked node
+	chunkManifestMagic = "zkclient:chunk:"
+
+	// chunkNodePrefix prefix of the child nodes holding chunk data
+	chunkNodePrefix = "_chunk-"
+)
+
+// chunkManifest describe the chunks of a large value.
+// The manifest is stored in the value node, and the chunks are stored in child nodes of the value node.
+type chunkManifest struct {
+	Generation string `json:"generation"`
+	Size       int    `json:"size"`
+	Chunks     int    `json:"chunks"`
+	Checksum   string `json:"checksum"`
+}
+
+func newChunkManifest(data []byte, chunkSize int) *chunkManifest {
+	return &chunkManifest{
+		Generation: strconv.FormatInt(time.Now().UnixNano(), 36),
+		Size:       len(data),
+		Chunks:     (len(data) + chunkSize - 1) / chunkSize,
+		Checksum:   chunkChecksum(data),
+	}
+}
+
+// parseChunkManifest parse manifest from node data, return false if the data is not a manifest
+func parseChunkManifest(data []byte) (*chunkManifest, bool) {
+	if !bytes.HasPrefix(data, []byte(chunkManifestMagic)) {
+		return nil, false
+	}
+
+	m := &chunkManifest{}
+	if err := json.Unmarshal(data[len(chunkManifestMagic):], m); err != nil {
+		return nil, false
+	}
+
+	return m, true
+}
+
+func (m *chunkManifest) Encode() ([]byte, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(chunkManifestMagic), data...), nil
+}
+
+// chunkNode return the chunk node name of the index
+func (m *chunkManifest) chunkNode(index int) string {
+	return fmt.Sprintf("%s%s-%d", chunkNodePrefix, m.Generation, index)
+}
+
+// Verify check the reassembled data against the manifest
+func (m *chunkManifest) Verify(data []byte) error {
+	if len(data) != m.Size || chunkChecksum(data) != m.Checksum {
+		return errChunkChecksum
+	}
+
+	return nil
+}
+
+func chunkChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// splitChunks split data into chunks of chunkSize
+func splitChunks(data []byte, chunkSize int) [][]byte {
+	chunks := make([][]byte, 0, (len(data)+chunkSize-1)/chunkSize)
+
+	for len(data) > chunkSize {
+		chunks = append(chunks, data[:chunkSize])
+		data = data[chunkSize:]
+	}
+
+	return append(chunks, data)
+}
+
+func isChunkNode(name string) bool {
+	return strings.HasPrefix(name, chunkNodePrefix)
+}
+
+// resolveRawValue reassemble the value if the data is a chunk manifest, otherwise return the data directly
+func (cli *Client) resolveRawValue(path string, data []byte) ([]byte, error) {
+	m, ok := parseChunkManifest(data)
+	if !ok {
+		return data, nil
+	}
+
+	buf := make([]byte, 0, m.Size)
+
+	for i := 0; i < m.Chunks; i++ {
+		chunk, _, err := cli.Conn().Get(PathJoin(path, m.chunkNode(i)))
+		if err != nil {
+			return nil, err
+		}
+
+		buf = append(buf, chunk...)
+	}
+
+	if err := m.Verify(buf); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// getRawValue get the raw value of the path, chunked value will be reassembled
+func (cli *Client) getRawValue(ctx context.Context, path string) (data []byte, stat *zk.Stat, err error) {
+	_, o := cli.startOperation(ctx, OpGet, path)
+	defer func() { o.end(err) }()
+
+	data, stat, err = cli.Conn().Get(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	o.version(stat.Version)
+
+	data, err = cli.resolveRawValue(path, data)
+
+	return data, stat, err
+}
+
+// chunkNodes list the chunk child nodes of the path
+func (cli *Client) chunkNodes(path string) ([]string, error) {
+	children, _, err := cli.Conn().Children(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []string
+
+	for _, child := range children {
+		if isChunkNode(child) {
+			nodes = append(nodes, child)
+		}
+	}
+
+	return nodes, nil
+}
+
+// setChunkedValue set value in chunked mode, version -1 matches any version, return the new version.
+// The chunks of a new generation are created first, then the manifest is updated
+// and the chunks of the old generation are deleted in one transaction,
+// so that readers always see a complete version.
+func (cli *Client) setChunkedValue(path string, data []byte, version int32) (int32, error) {
+	for {
+		newVersion, err := cli.trySetChunkedValue(path, data, version)
+
+		// retry if changed by others between reading and writing, unless the version is required
+		if err == zk.ErrBadVersion && version < 0 {
+			continue
+		}
+
+		return newVersion, err
+	}
+}
+
+// trySetChunkedValue set value in chunked mode by the version read, see setChunkedValue
+func (cli *Client) trySetChunkedValue(path string, data []byte, version int32) (int32, error) {
+	if err := cli.ensurePath(path); err != nil {
+		return noVersion, err
+	}
+
+	_, stat, err := cli.Conn().Get(path)
+	if err != nil {
+		return noVersion, err
+	}
+
+	if version >= 0 && stat.Version != version {
+		return noVersion, zk.ErrBadVersion
+	}
+
+	oldChunks, err := cli.chunkNodes(path)
+	if err != nil {
+		return noVersion, err
+	}
+
+	if len(data) <= cli.chunkSize && len(oldChunks) == 0 {
+		if stat, err = cli.Conn().Set(path, data, stat.Version); err != nil {
+			return noVersion, err
+		}
+
+		return stat.Version, nil
+	}
+
+	value := data
+
+	var newChunks []string
+
+	if len(data) > cli.chunkSize {
+		m := newChunkManifest(data, cli.chunkSize)
+
+		for i, chunk := range splitChunks(data, cli.chunkSize) {
+			chunkPath := PathJoin(path, m.chunkNode(i))
+			if _, err = cli.Conn().Create(chunkPath, chunk, 0, zk.WorldACL(zk.PermAll)); err != nil {
+				cli.deleteNodes(newChunks)
+				return noVersion, err
+			}
+
+			newChunks = append(newChunks, chunkPath)
+		}
+
+		if value, err = m.Encode(); err != nil {
+			cli.deleteNodes(newChunks)
+			return noVersion, err
+		}
+	}
+
+	ops := []interface{}{&zk.SetDataRequest{Path: path, Data: value, Version: stat.Version}}
+	for _, chunk := range oldChunks {
+		ops = append(ops, &zk.DeleteRequest{Path: PathJoin(path, chunk), Version: -1})
+	}
+
+	if err = cli.transaction(ops); err != nil {
+		cli.deleteNodes(newChunks)
+		return noVersion, err
+	}
+
+	// the manifest is set by the exact version in the transaction
+	return stat.Version + 1, nil
+}
+
+// deleteChunkedValue delete the value node together with its chunk nodes in one transaction
+func (cli *Client) deleteChunkedValue(path string) error {
+	chunks, err := cli.chunkNodes(path)
+	if err != nil {
+		return err
+	}
+
+	if len(chunks) == 0 {
+		return zk.ErrNotEmpty
+	}
+
+	ops := make([]interface{}, 0, len(chunks)+1)
+	for _, chunk := range chunks {
+		ops = append(ops, &zk.DeleteRequest{Path: PathJoin(path, chunk), Version: -1})
+	}
+
+	ops = append(ops, &zk.DeleteRequest{Path: path, Version: -1})
+
+	return cli.transaction(ops)
+}
+
+// deleteNodes delete nodes ignoring errors, used to clean up after failure
+func (cli *Client) deleteNodes(paths []string) {
+	for _, p := range paths {
+		if err := cli.conn.Delete(p, -1); err != nil && err != zk.ErrNoNode {
+			cli.log().Warn("zk failed to delete node", "path", p, "error", err)
+		}
+	}
+}
+
+// multi check the write policy, execute operations in one transaction and audit each of them,
+// return the first error
+func (cli *Client) multi(ops ...interface{}) error {
+	return cli.multiContext(context.Background(), ops...)
+}
+
+// multiContext execute operations same as multi, the ctx carries the actor of audit records
+func (cli *Client) multiContext(ctx context.Context, ops ...interface{}) error {
+	if err := cli.checkOps(ops); err != nil {
+		cli.auditOps(ctx, ops, nil, err)
+		return err
+	}
+
+	responses, err := cli.Conn().Multi(ops...)
+	if err == nil {
+		err = firstError(responses)
+	}
+
+	cli.auditOps(ctx, ops, responses, err)
+
+	return err
+}
+
+// transaction execute operations in one transaction, and return the first error,
+// used for chunk nodes checked and audited together with the value node.
+func (cli *Client) transaction(ops []interface{}) error {
+	responses, err := cli.Conn().Multi(ops...)
+	if err != nil {
+		return err
+	}
+
+	return firstError(responses)
+}
+
+// firstError the first error of the responses
+func firstError(responses []zk.MultiResponse) error {
+	for _, res := range responses {
+		if res.Error != nil {
+			return res.Error
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkManifest(t *testing.T) {
+	data := []byte(strings.Repeat("0123456789", 10))
+
+	chunks := splitChunks(data, 30)
+	assert.Equal(t, 4, len(chunks))
+	assert.Equal(t, 10, len(chunks[3]))
+	assert.Equal(t, data, bytes.Join(chunks, nil))
+
+	m := newChunkManifest(data, 30)
+	assert.Equal(t, 4, m.Chunks)
+	assert.Nil(t, m.Verify(data))
+	assert.Equal(t, errChunkChecksum, m.Verify(data[1:]))
+
+	encoded, err := m.Encode()
+	assert.Nil(t, err)
+
+	parsed, ok := parseChunkManifest(encoded)
+	assert.True(t, ok)
+	assert.Equal(t, m, parsed)
+	assert.True(t, isChunkNode(parsed.chunkNode(0)))
+
+	_, ok = parseChunkManifest(data)
+	assert.False(t, ok)
+}
+
+func TestClient_ChunkedValue(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/chunked"
+	large := strings.Repeat("chunk", 100)
+
+	c := NewClient([]string{"127.0.0.1:2181"}, WithChunkSize(64))
+	defer c.Close()
+
+	var s string
+	w, err := c.SyncWatchString(path, &s, nil)
+	assert.Nil(t, err)
+
+	waitEventWatch()
+
+	err = c.SetString(path, large)
+	assert.Nil(t, err)
+
+	waitEventWatch()
+
+	assert.Equal(t, large, s)
+
+	data, err := c.GetString(path)
+	assert.Nil(t, err)
+	assert.Equal(t, large, data)
+
+	err = c.SetString(path, "small")
+	assert.Nil(t, err)
+
+	waitEventWatch()
+
+	assert.Equal(t, "small", s)
+
+	chunks, err := c.chunkNodes(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(chunks))
+
+	err = c.SetString(path, large)
+	assert.Nil(t, err)
+
+	err = c.Delete(path)
+	assert.Nil(t, err)
+
+	w.Close()
+}
+
+func TestClient_ChunkedValueConcurrentSet(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/chunked_concurrent"
+	large := strings.Repeat("chunk", 100)
+
+	c := NewClient([]string{"127.0.0.1:2181"}, WithChunkSize(64))
+	defer c.Close()
+
+	errs := make(chan error, 4)
+
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			errs <- c.SetString(path, large)
+		}()
+	}
+
+	// unconditional sets never fail for version conflicts
+	for i := 0; i < cap(errs); i++ {
+		assert.Nil(t, <-errs)
+	}
+
+	data, err := c.GetString(path)
+	assert.Nil(t, err)
+	assert.Equal(t, large, data)
+
+	_ = c.Delete(path)
+}
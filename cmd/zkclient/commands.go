@@ -0,0 +1,294 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/vogo/zkclient"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	register(&command{name: "ls", usage: "[-r] <path>  list children, recursively with -r", run: runLs})
+	register(&command{name: "get", usage: "[-codec string|json|yaml|raw] [-stat] <path>  get decoded value", run: runGet})
+	register(&command{
+		name:  "set",
+		usage: "[-version n] [-file f] <path> [value]  set value, read from file or stdin (-) if no value",
+		run:   runSet,
+	})
+	register(&command{name: "create", usage: "[-e] [-s] <path> [value]  create ephemeral and/or sequential node", run: runCreate})
+	register(&command{name: "rm", usage: "[-r] <path>  delete node, recursively with -r", run: runRm})
+	register(&command{name: "stat", usage: "<path>  get node stat", run: runStat})
+	register(&command{name: "watch", usage: "<path>  stream value and children events until interrupted", run: runWatch})
+}
+
+// nodeResult json result of a node
+type nodeResult struct {
+	Path  string             `json:"path"`
+	Value interface{}        `json:"value,omitempty"`
+	Stat  *zkclient.NodeStat `json:"stat,omitempty"`
+}
+
+// decodeValue decode data by the codec name for printing as json
+func decodeValue(data []byte, codec string) (interface{}, error) {
+	switch codec {
+	case "string":
+		return string(data), nil
+	case "raw":
+		return data, nil
+	case "json", "yaml":
+		if len(data) == 0 {
+			return nil, nil
+		}
+
+		var v interface{}
+
+		if codec == "json" {
+			return v, json.Unmarshal(data, &v)
+		}
+
+		return v, yaml.Unmarshal(data, &v)
+	default:
+		return nil, fmt.Errorf("unknown codec: %s", codec)
+	}
+}
+
+func runLs(e *env, args []string) error {
+	fs := flag.NewFlagSet("ls", flag.ContinueOnError)
+	recursive := fs.Bool("r", false, "list recursively")
+
+	args, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	root, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	paths := []string{}
+
+	var list func(p string) error
+
+	list = func(p string) error {
+		children, err := e.client.GetChildren(p)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			c := childPath(p, child)
+			paths = append(paths, e.relative(c))
+
+			if *recursive {
+				if err := list(c); err != nil && err != zk.ErrNoNode {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err = list(root); err != nil {
+		return err
+	}
+
+	return e.print(paths)
+}
+
+func runGet(e *env, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	codec := fs.String("codec", "string", "decode value as string, json, yaml or raw (base64)")
+	withStat := fs.Bool("stat", false, "print stat")
+
+	args, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	data, stat, err := e.client.GetRawValue(p)
+	if err != nil {
+		return err
+	}
+
+	value, err := decodeValue(data, *codec)
+	if err != nil {
+		return err
+	}
+
+	result := &nodeResult{Path: args[0], Value: value}
+	if *withStat {
+		result.Stat = zkclient.NewNodeStat(stat)
+	}
+
+	return e.print(result)
+}
+
+// readValue read value from the arg, the file, or stdin if the file is -
+func readValue(args []string, index int, file string) ([]byte, error) {
+	if len(args) > index {
+		return []byte(args[index]), nil
+	}
+
+	switch file {
+	case "":
+		return nil, nil
+	case "-":
+		return ioutil.ReadAll(os.Stdin)
+	default:
+		return ioutil.ReadFile(file)
+	}
+}
+
+func runSet(e *env, args []string) error {
+	fs := flag.NewFlagSet("set", flag.ContinueOnError)
+	version := fs.Int("version", -1, "set only if the node version matches")
+	file := fs.String("file", "-", "read value from the file, - for stdin")
+
+	args, err := parseArgs(fs, args, 1, 2)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	data, err := readValue(args, 1, *file)
+	if err != nil {
+		return err
+	}
+
+	if *version >= 0 {
+		err = e.client.SetRawValueVersion(p, data, int32(*version))
+	} else {
+		err = e.client.SetRawValue(p, data)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, stat, err := e.client.Conn().Exists(p)
+	if err != nil {
+		return err
+	}
+
+	return e.print(&nodeResult{Path: args[0], Stat: zkclient.NewNodeStat(stat)})
+}
+
+func runCreate(e *env, args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	ephemeral := fs.Bool("e", false, "create ephemeral node, deleted when the command exits")
+	sequential := fs.Bool("s", false, "create sequential node")
+	file := fs.String("file", "", "read value from the file, - for stdin")
+
+	args, err := parseArgs(fs, args, 1, 2)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	data, err := readValue(args, 1, *file)
+	if err != nil {
+		return err
+	}
+
+	var flags int32
+
+	if *ephemeral {
+		flags |= zk.FlagEphemeral
+	}
+
+	if *sequential {
+		flags |= zk.FlagSequence
+	}
+
+	if parent := zkclient.ParentNode(p); parent != "" {
+		if err = e.client.EnsurePath(parent); err != nil {
+			return err
+		}
+	}
+
+	created, err := e.client.Create(p, data, flags)
+	if err != nil {
+		return err
+	}
+
+	return e.print(&nodeResult{Path: e.relative(created)})
+}
+
+func runRm(e *env, args []string) error {
+	fs := flag.NewFlagSet("rm", flag.ContinueOnError)
+	recursive := fs.Bool("r", false, "delete recursively")
+
+	args, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	if p == zkclient.PathSplit {
+		return fmt.Errorf("can't delete root")
+	}
+
+	if *recursive {
+		err = e.client.DeleteRecursive(p)
+	} else {
+		err = e.client.Delete(p)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return e.print(&nodeResult{Path: args[0]})
+}
+
+func runStat(e *env, args []string) error {
+	args, err := parseArgs(flag.NewFlagSet("stat", flag.ContinueOnError), args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	exists, stat, err := e.client.Conn().Exists(p)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return zk.ErrNoNode
+	}
+
+	return e.print(&nodeResult{Path: args[0], Stat: zkclient.NewNodeStat(stat)})
+}
@@ -0,0 +1,196 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+// Command zkclient operate zookeeper nodes from the command line, all results are printed as json for scripting.
+//
+//	zkclient [-servers host:port,...] [-namespace /ns] [-auth scheme:auth] <command> [flags] <path> [value]
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vogo/logger"
+	"github.com/vogo/zkclient"
+)
+
+// command sub command of the tool
+type command struct {
+	name  string
+	usage string
+	run   func(e *env, args []string) error
+}
+
+var commands []*command
+
+func register(c *command) {
+	commands = append(commands, c)
+}
+
+// env of running command
+type env struct {
+	client    *zkclient.Client
+	namespace string
+	out       io.Writer
+}
+
+// resolve user path to zookeeper path under the namespace
+func (e *env) resolve(p string) (string, error) {
+	if !strings.HasPrefix(p, zkclient.PathSplit) {
+		return "", fmt.Errorf("path must start with /: %s", p)
+	}
+
+	return path.Join(zkclient.PathSplit, e.namespace, p), nil
+}
+
+// relative zookeeper path to user path under the namespace
+func (e *env) relative(p string) string {
+	if e.namespace == "" {
+		return p
+	}
+
+	p = strings.TrimPrefix(p, path.Join(zkclient.PathSplit, e.namespace))
+	if p == "" {
+		return zkclient.PathSplit
+	}
+
+	return p
+}
+
+// print result as json line
+func (e *env) print(v interface{}) error {
+	return json.NewEncoder(e.out).Encode(v)
+}
+
+// childPath join the child to the parent path
+func childPath(parent, child string) string {
+	if parent == zkclient.PathSplit {
+		return parent + child
+	}
+
+	return parent + zkclient.PathSplit + child
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: zkclient [flags] <command> [command flags] <args>\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\ncommands:\n")
+
+	sort.Slice(commands, func(i, j int) bool { return commands[i].name < commands[j].name })
+
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.usage)
+	}
+}
+
+func main() {
+	servers := flag.String("servers", "127.0.0.1:2181", "comma separated zookeeper servers")
+	namespace := flag.String("namespace", "", "namespace prefixed to all paths")
+	auth := flag.String("auth", "", "auth of `scheme:auth`, e.g. digest:user:password")
+	timeout := flag.Duration("timeout", time.Second*5, "session and connecting timeout")
+	verbose := flag.Bool("v", false, "print debug logs to stderr")
+
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logger.LevelError)
+
+	if *verbose {
+		logger.SetLevel(logger.LevelDebug)
+	}
+
+	var cmd *command
+
+	for _, c := range commands {
+		if c.name == flag.Arg(0) {
+			cmd = c
+		}
+	}
+
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	options := []zkclient.ClientOption{zkclient.WithTimeout(*timeout)}
+
+	if *auth != "" {
+		idx := strings.Index(*auth, ":")
+		if idx <= 0 {
+			exit(errors.New("auth should be scheme:auth"))
+		}
+
+		options = append(options, zkclient.WithAuth((*auth)[:idx], []byte((*auth)[idx+1:])))
+	}
+
+	client := zkclient.NewClient(strings.Split(*servers, ","), options...)
+	defer client.Close()
+
+	if err := waitConnected(client, *timeout); err != nil {
+		exit(err)
+	}
+
+	e := &env{
+		client:    client,
+		namespace: strings.Trim(*namespace, zkclient.PathSplit),
+		out:       os.Stdout,
+	}
+
+	if err := cmd.run(e, flag.Args()[1:]); err != nil {
+		client.Close()
+		exit(err)
+	}
+}
+
+func waitConnected(client *zkclient.Client, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for !client.ConnAlive() {
+		if time.Now().After(deadline) {
+			return errors.New("failed to connect zookeeper")
+		}
+
+		time.Sleep(time.Millisecond * 50)
+	}
+
+	return nil
+}
+
+// exit print the error as json to stderr and exit
+func exit(err error) {
+	_ = json.NewEncoder(os.Stderr).Encode(map[string]string{"error": err.Error()})
+
+	os.Exit(1)
+}
+
+// parseArgs parse command flags, and check the count of positional args
+func parseArgs(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	fs.SetOutput(os.Stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() < min || fs.NArg() > max {
+		return nil, fmt.Errorf("%s: expected %d to %d args, got %d", fs.Name(), min, max, fs.NArg())
+	}
+
+	return fs.Args(), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/zkclient"
+)
+
+func TestResolvePath(t *testing.T) {
+	e := &env{}
+
+	p, err := e.resolve("/a/b")
+	assert.Nil(t, err)
+	assert.Equal(t, "/a/b", p)
+	assert.Equal(t, "/a/b", e.relative(p))
+
+	_, err = e.resolve("a")
+	assert.NotNil(t, err)
+
+	e.namespace = "ns"
+
+	p, _ = e.resolve("/a/b")
+	assert.Equal(t, "/ns/a/b", p)
+	assert.Equal(t, "/a/b", e.relative(p))
+
+	p, _ = e.resolve("/")
+	assert.Equal(t, "/ns", p)
+	assert.Equal(t, "/", e.relative(p))
+
+	assert.Equal(t, "/a", childPath("/", "a"))
+	assert.Equal(t, "/ns/a", childPath("/ns", "a"))
+}
+
+func TestDecodeValue(t *testing.T) {
+	v, err := decodeValue([]byte(`{"name":"x","n":1}`), "json")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "x", "n": float64(1)}, v)
+
+	v, err = decodeValue([]byte("name: x\nn: 1\n"), "yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "x", "n": 1}, v)
+
+	v, err = decodeValue([]byte("x"), "string")
+	assert.Nil(t, err)
+	assert.Equal(t, "x", v)
+
+	v, err = decodeValue(nil, "json")
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	_, err = decodeValue(nil, "xml")
+	assert.NotNil(t, err)
+}
+
+func TestDocumentFormat(t *testing.T) {
+	format, err := documentFormat("", "tree.yml")
+	assert.Nil(t, err)
+	assert.Equal(t, "yaml", format)
+
+	format, _ = documentFormat("", "-")
+	assert.Equal(t, "json", format)
+
+	_, err = documentFormat("xml", "")
+	assert.NotNil(t, err)
+
+	doc := &zkclient.TreeDocument{
+		Path: "/a",
+		Root: &zkclient.TreeNode{Value: "1", Children: map[string]*zkclient.TreeNode{"b": zkclient.NewTreeNode([]byte{0xff})}},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		data, err := marshalDocument(doc, format)
+		assert.Nil(t, err)
+
+		parsed, err := unmarshalDocument(data, format)
+		assert.Nil(t, err)
+		assert.Equal(t, doc, parsed)
+	}
+}
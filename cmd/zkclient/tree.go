@@ -0,0 +1,243 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/vogo/zkclient"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	register(&command{name: "export", usage: "[-stat] [-format json|yaml] [-o file] <path>  export subtree document", run: runExport})
+	register(&command{
+		name:  "diff",
+		usage: "[-prune] [-format json|yaml] [-f file] [path]  diff live subtree to document",
+		run:   runDiff,
+	})
+	register(&command{
+		name:  "apply",
+		usage: "[-prune] [-format json|yaml] [-f file] [path]  apply document to live subtree with version checks",
+		run:   runApply,
+	})
+	register(&command{
+		name:  "import",
+		usage: "[-mode merge|overwrite] [-dry-run] [-format json|yaml] [-f file] [path]  import subtree document",
+		run:   runImport,
+	})
+}
+
+// documentFormat format of the flag, or detected by the file extension
+func documentFormat(format, file string) (string, error) {
+	if format == "" {
+		switch filepath.Ext(file) {
+		case ".yaml", ".yml":
+			format = "yaml"
+		default:
+			format = "json"
+		}
+	}
+
+	if format != "json" && format != "yaml" {
+		return "", fmt.Errorf("unknown format: %s", format)
+	}
+
+	return format, nil
+}
+
+func marshalDocument(doc *zkclient.TreeDocument, format string) ([]byte, error) {
+	if format == "yaml" {
+		return yaml.Marshal(doc)
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func unmarshalDocument(data []byte, format string) (*zkclient.TreeDocument, error) {
+	doc := &zkclient.TreeDocument{}
+
+	if format == "yaml" {
+		return doc, yaml.Unmarshal(data, doc)
+	}
+
+	return doc, json.Unmarshal(data, doc)
+}
+
+func runExport(e *env, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	withStat := fs.Bool("stat", false, "export node stats")
+	format := fs.String("format", "", "json or yaml, detected by the output file extension if empty")
+	output := fs.String("o", "", "output file, stdout if empty")
+
+	args, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	if *format, err = documentFormat(*format, *output); err != nil {
+		return err
+	}
+
+	doc, err := e.client.Export(p, *withStat)
+	if err != nil {
+		return err
+	}
+
+	doc.Path = args[0]
+
+	data, err := marshalDocument(doc, *format)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = e.out.Write(append(data, '\n'))
+		return err
+	}
+
+	return ioutil.WriteFile(*output, data, 0644)
+}
+
+// readDocument read the document from the input file, and resolve the target path from args or the document
+func readDocument(e *env, input, format string, args []string) (*zkclient.TreeDocument, string, error) {
+	format, err := documentFormat(format, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := readValue(nil, 0, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	doc, err := unmarshalDocument(data, format)
+	if err != nil {
+		return nil, "", err
+	}
+
+	target := doc.Path
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	p, err := e.resolve(target)
+
+	return doc, p, err
+}
+
+// printDiff print the diff with paths relative to the namespace
+func printDiff(e *env, diff *zkclient.TreeDiff) error {
+	diff.Path = e.relative(diff.Path)
+	for _, c := range diff.Changes {
+		c.Path = e.relative(c.Path)
+	}
+
+	return e.print(diff)
+}
+
+func runDiff(e *env, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "include live nodes not in the document as removed")
+	format := fs.String("format", "", "json or yaml, detected by the input file extension if empty")
+	input := fs.String("f", "-", "input file, - for stdin")
+
+	args, err := parseArgs(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	doc, p, err := readDocument(e, *input, *format, args)
+	if err != nil {
+		return err
+	}
+
+	diff, err := e.client.Diff(p, doc.Root, *prune)
+	if err != nil {
+		return err
+	}
+
+	return printDiff(e, diff)
+}
+
+func runApply(e *env, args []string) error {
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "delete live nodes not in the document")
+	format := fs.String("format", "", "json or yaml, detected by the input file extension if empty")
+	input := fs.String("f", "-", "input file, - for stdin")
+
+	args, err := parseArgs(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	doc, p, err := readDocument(e, *input, *format, args)
+	if err != nil {
+		return err
+	}
+
+	diff, err := e.client.Reconcile(p, doc.Root, *prune)
+	if err != nil {
+		return err
+	}
+
+	return printDiff(e, diff)
+}
+
+func runImport(e *env, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	modeName := fs.String("mode", "merge", "merge keeps nodes not in the document, overwrite deletes them")
+	dryRun := fs.Bool("dry-run", false, "print changes without applying")
+	format := fs.String("format", "", "json or yaml, detected by the input file extension if empty")
+	input := fs.String("f", "-", "input file, - for stdin")
+
+	args, err := parseArgs(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	var mode zkclient.ImportMode
+
+	switch *modeName {
+	case "merge":
+		mode = zkclient.ImportMerge
+	case "overwrite":
+		mode = zkclient.ImportOverwrite
+	default:
+		return fmt.Errorf("unknown mode: %s", *modeName)
+	}
+
+	if *dryRun {
+		mode |= zkclient.ImportDryRun
+	}
+
+	doc, p, err := readDocument(e, *input, *format, args)
+	if err != nil {
+		return err
+	}
+
+	result, err := e.client.Import(p, doc, mode)
+	if err != nil {
+		return err
+	}
+
+	for _, paths := range [][]string{result.Created, result.Updated, result.Deleted} {
+		for i := range paths {
+			paths[i] = e.relative(paths[i])
+		}
+	}
+
+	return e.print(result)
+}
@@ -0,0 +1,158 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/vogo/zkclient"
+)
+
+// watchEvent json line printed for each event
+type watchEvent struct {
+	Event    string             `json:"event"`
+	Path     string             `json:"path"`
+	Value    interface{}        `json:"value,omitempty"`
+	Children []string           `json:"children,omitempty"`
+	Stat     *zkclient.NodeStat `json:"stat,omitempty"`
+}
+
+// eventPrinter print events of handlers in order
+type eventPrinter struct {
+	sync.Mutex
+	env   *env
+	codec string
+}
+
+func (p *eventPrinter) print(evt *watchEvent) {
+	p.Lock()
+	defer p.Unlock()
+
+	_ = p.env.print(evt)
+}
+
+func eventName(evt *zk.Event) string {
+	if evt == nil {
+		return "init"
+	}
+
+	return evt.Type.String()
+}
+
+// valueWatchHandler print value events of the node
+type valueWatchHandler struct {
+	printer *eventPrinter
+	path    string
+}
+
+func (h *valueWatchHandler) Path() string {
+	return h.path
+}
+
+func (h *valueWatchHandler) Handle(_ *zkclient.Watcher, evt *zk.Event) (<-chan zk.Event, error) {
+	exists, stat, ch, err := h.printer.env.client.Conn().ExistsW(h.path)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &watchEvent{Event: eventName(evt), Path: h.printer.env.relative(h.path)}
+
+	if exists {
+		data, _, err := h.printer.env.client.GetRawValue(h.path)
+		if err != nil && err != zk.ErrNoNode {
+			return nil, err
+		}
+
+		if e.Value, err = decodeValue(data, h.printer.codec); err != nil {
+			e.Value = string(data)
+		}
+
+		e.Stat = zkclient.NewNodeStat(stat)
+	}
+
+	h.printer.print(e)
+
+	return ch, nil
+}
+
+// childrenWatchHandler print children events of the node
+type childrenWatchHandler struct {
+	printer *eventPrinter
+	path    string
+}
+
+func (h *childrenWatchHandler) Path() string {
+	return h.path
+}
+
+func (h *childrenWatchHandler) Handle(_ *zkclient.Watcher, evt *zk.Event) (<-chan zk.Event, error) {
+	children, _, ch, err := h.printer.env.client.Conn().ChildrenW(h.path)
+	if err == zk.ErrNoNode {
+		// wait for the node to be created
+		_, _, ch, err = h.printer.env.client.Conn().ExistsW(h.path)
+		if err != nil {
+			return nil, err
+		}
+
+		return ch, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if evt == nil || evt.Type == zk.EventNodeChildrenChanged {
+		h.printer.print(&watchEvent{
+			Event:    eventName(evt),
+			Path:     h.printer.env.relative(h.path),
+			Children: children,
+		})
+	}
+
+	return ch, nil
+}
+
+func runWatch(e *env, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	codec := fs.String("codec", "string", "decode value as string, json, yaml or raw (base64)")
+
+	args, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	printer := &eventPrinter{env: e, codec: *codec}
+
+	for _, handler := range []zkclient.EventHandler{
+		&valueWatchHandler{printer: printer, path: p},
+		&childrenWatchHandler{printer: printer, path: p},
+	} {
+		watcher, err := e.client.NewWatcher(handler)
+		if err != nil {
+			return err
+		}
+
+		watcher.Watch()
+
+		defer watcher.Close()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// ChildEvent change of a child node
+type ChildEvent struct {
+	Child string
+	Stat  *zk.Stat
+	Value interface{}
+}
+
+// ChildBatch changes of child nodes coalesced within a window
+type ChildBatch struct {
+	Path    string
+	Added   []*ChildEvent
+	Updated []*ChildEvent
+	Removed []*ChildEvent
+}
+
+// BatchListener listen coalesced child changes,
+// a ChildListener also implementing BatchListener receives a single Batch call instead of Update/Delete calls.
+type BatchListener interface {
+	Batch(batch *ChildBatch)
+}
+
+// childCoalescer collect child changes within a window, and deliver them once
+type childCoalescer struct {
+	lock     sync.Mutex
+	path     string
+	window   time.Duration
+	listener ChildListener
+	known    map[string]struct{}
+	pending  map[string]*ChildEvent
+	deleted  map[string]struct{}
+	timer    *time.Timer
+	closed   bool
+}
+
+func newChildCoalescer(path string, window time.Duration, listener ChildListener) *childCoalescer {
+	return &childCoalescer{
+		path:     path,
+		window:   window,
+		listener: listener,
+		known:    make(map[string]struct{}),
+		pending:  make(map[string]*ChildEvent),
+		deleted:  make(map[string]struct{}),
+	}
+}
+
+// Update collect child update
+func (c *childCoalescer) Update(_, child string, stat *zk.Stat, obj interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	delete(c.deleted, child)
+	c.pending[child] = &ChildEvent{Child: child, Stat: stat, Value: obj}
+	c.schedule()
+}
+
+// Delete collect child deletion
+func (c *childCoalescer) Delete(_, child string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	delete(c.pending, child)
+	c.deleted[child] = nilStruct
+	c.schedule()
+}
+
+// schedule flush at the end of the window started by the first pending change
+func (c *childCoalescer) schedule() {
+	if c.timer == nil {
+		c.timer = time.AfterFunc(c.window, c.flush)
+	}
+}
+
+// closeOn close the coalescer once the watcher or the client closed
+func (c *childCoalescer) closeOn(watcherDone, clientDone <-chan struct{}) {
+	go func() {
+		select {
+		case <-watcherDone:
+		case <-clientDone:
+		}
+
+		c.Close()
+	}()
+}
+
+// Close stop the pending flush and drop pending changes
+func (c *childCoalescer) Close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	c.pending = make(map[string]*ChildEvent)
+	c.deleted = make(map[string]struct{})
+}
+
+// flush deliver the pending changes
+func (c *childCoalescer) flush() {
+	c.lock.Lock()
+
+	if c.closed {
+		c.lock.Unlock()
+		return
+	}
+
+	batch := c.collect()
+	c.lock.Unlock()
+
+	if len(batch.Added)+len(batch.Updated)+len(batch.Removed) == 0 {
+		return
+	}
+
+	if l, ok := c.listener.(BatchListener); ok {
+		l.Batch(batch)
+		return
+	}
+
+	for _, events := range [][]*ChildEvent{batch.Added, batch.Updated} {
+		for _, e := range events {
+			c.listener.Update(c.path, e.Child, e.Stat, e.Value)
+		}
+	}
+
+	for _, e := range batch.Removed {
+		c.listener.Delete(c.path, e.Child)
+	}
+}
+
+// collect build batch from pending changes, must be called with lock held
+func (c *childCoalescer) collect() *ChildBatch {
+	batch := &ChildBatch{Path: c.path}
+
+	for child, e := range c.pending {
+		if _, ok := c.known[child]; ok {
+			batch.Updated = append(batch.Updated, e)
+		} else {
+			c.known[child] = nilStruct
+			batch.Added = append(batch.Added, e)
+		}
+	}
+
+	for child := range c.deleted {
+		// ignore child added and removed within the window
+		if _, ok := c.known[child]; ok {
+			delete(c.known, child)
+			batch.Removed = append(batch.Removed, &ChildEvent{Child: child})
+		}
+	}
+
+	c.pending = make(map[string]*ChildEvent)
+	c.deleted = make(map[string]struct{})
+	c.timer = nil
+
+	sortChildEvents(batch.Added)
+	sortChildEvents(batch.Updated)
+	sortChildEvents(batch.Removed)
+
+	return batch
+}
+
+func sortChildEvents(events []*ChildEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Child < events[j].Child
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+type batchListener struct {
+	mListener
+	batches chan *ChildBatch
+}
+
+func (l *batchListener) Batch(batch *ChildBatch) {
+	l.batches <- batch
+}
+
+func TestChildCoalescer(t *testing.T) {
+	l := &batchListener{batches: make(chan *ChildBatch, 1)}
+	c := newChildCoalescer("/test/users", time.Millisecond*50, l)
+
+	c.Update("/test/users", "u1", &zk.Stat{}, "v1")
+	c.Update("/test/users", "u2", &zk.Stat{}, "v2")
+	c.Update("/test/users", "u1", &zk.Stat{}, "v1.1")
+	c.Update("/test/users", "u3", &zk.Stat{}, "v3")
+	c.Delete("/test/users", "u3")
+
+	batch := <-l.batches
+	assert.Equal(t, 2, len(batch.Added))
+	assert.Equal(t, "u1", batch.Added[0].Child)
+	assert.Equal(t, "v1.1", batch.Added[0].Value)
+	assert.Equal(t, "u2", batch.Added[1].Child)
+	assert.Equal(t, 0, len(batch.Updated))
+	assert.Equal(t, 0, len(batch.Removed))
+
+	c.Update("/test/users", "u1", &zk.Stat{}, "v1.2")
+	c.Delete("/test/users", "u2")
+
+	batch = <-l.batches
+	assert.Equal(t, 0, len(batch.Added))
+	assert.Equal(t, 1, len(batch.Updated))
+	assert.Equal(t, "v1.2", batch.Updated[0].Value)
+	assert.Equal(t, 1, len(batch.Removed))
+	assert.Equal(t, "u2", batch.Removed[0].Child)
+}
+
+func TestChildCoalescerClose(t *testing.T) {
+	l := &batchListener{batches: make(chan *ChildBatch, 1)}
+	c := newChildCoalescer("/test/users", time.Millisecond*20, l)
+
+	watcherDone := make(chan struct{})
+	c.closeOn(watcherDone, nil)
+
+	c.Update("/test/users", "u1", &zk.Stat{}, "v1")
+	close(watcherDone)
+
+	select {
+	case batch := <-l.batches:
+		t.Fatalf("unexpected batch after closed: %v", batch)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// changes after closed are dropped
+	c.Update("/test/users", "u2", &zk.Stat{}, "v2")
+	assert.Empty(t, c.pending)
+}
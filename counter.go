@@ -0,0 +1,197 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	// counterMaxRetries max retries of CAS update under contention
+	counterMaxRetries = 64
+
+	sequenceNodePrefix = "id-"
+)
+
+// AtomicCounter cluster-wide counter stored in a node, updated by CAS
+type AtomicCounter struct {
+	client *Client
+	path   string
+}
+
+// NewAtomicCounter create atomic counter of the path
+func (cli *Client) NewAtomicCounter(path string) *AtomicCounter {
+	return &AtomicCounter{client: cli, path: path}
+}
+
+// load return the counter value and node version, the node is created if not exists
+func (c *AtomicCounter) load() (int64, int32, error) {
+	data, stat, err := c.client.Conn().Get(c.path)
+	if err == zk.ErrNoNode {
+		if err = c.client.EnsurePath(c.path); err != nil && err != zk.ErrNodeExists {
+			return 0, 0, err
+		}
+
+		data, stat, err = c.client.Conn().Get(c.path)
+	}
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(data) == 0 {
+		return 0, stat.Version, nil
+	}
+
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return value, stat.Version, nil
+}
+
+// Get the counter value
+func (c *AtomicCounter) Get() (int64, error) {
+	value, _, err := c.load()
+	return value, err
+}
+
+// Add delta to the counter, return the new value
+func (c *AtomicCounter) Add(delta int64) (int64, error) {
+	for i := 0; i < counterMaxRetries; i++ {
+		value, version, err := c.load()
+		if err != nil {
+			return 0, err
+		}
+
+		value += delta
+
+		err = c.store(value, version)
+		if err == zk.ErrBadVersion {
+			continue // updated by others, retry
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		return value, nil
+	}
+
+	return 0, zk.ErrBadVersion
+}
+
+// Increment add one to the counter, return the new value
+func (c *AtomicCounter) Increment() (int64, error) {
+	return c.Add(1)
+}
+
+// CompareAndSet set the counter to update if the current value equals expect
+func (c *AtomicCounter) CompareAndSet(expect, update int64) (bool, error) {
+	for i := 0; i < counterMaxRetries; i++ {
+		value, version, err := c.load()
+		if err != nil {
+			return false, err
+		}
+
+		if value != expect {
+			return false, nil
+		}
+
+		err = c.store(update, version)
+		if err == zk.ErrBadVersion {
+			continue // the value may be the same after updated by others, check again
+		}
+
+		return err == nil, err
+	}
+
+	return false, zk.ErrBadVersion
+}
+
+func (c *AtomicCounter) store(value int64, version int32) error {
+	return c.client.SetRawValueVersion(c.path, []byte(strconv.FormatInt(value, 10)), version)
+}
+
+// SequenceGenerator cluster-wide unique increasing id generator,
+// a sequential node is created for every batch of ids to reduce round trips.
+// Ids allocated by a generator are increasing, but ids of different generators interleave by batch.
+type SequenceGenerator struct {
+	lock      sync.Mutex
+	client    *Client
+	path      string
+	batchSize int64
+	next      int64
+	limit     int64
+}
+
+// NewSequenceGenerator create sequence generator of the path, allocating batchSize ids each time
+func (cli *Client) NewSequenceGenerator(path string, batchSize int) (*SequenceGenerator, error) {
+	if batchSize <= 0 {
+		return nil, errors.New("batch size should be positive")
+	}
+
+	return &SequenceGenerator{
+		client:    cli,
+		path:      path,
+		batchSize: int64(batchSize),
+	}, nil
+}
+
+// Next return the next id, the first id is 1
+func (g *SequenceGenerator) Next() (int64, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.next >= g.limit {
+		if err := g.allocate(); err != nil {
+			return 0, err
+		}
+	}
+
+	id := g.next
+	g.next++
+
+	return id, nil
+}
+
+// allocate a batch of ids from the sequence number of a new sequential node
+func (g *SequenceGenerator) allocate() error {
+	prefixPath := PathJoin(g.path, sequenceNodePrefix)
+
+	p, err := g.client.Create(prefixPath, nil, zk.FlagSequence)
+	if err == zk.ErrNoNode {
+		if err = g.client.EnsurePath(g.path); err != nil {
+			return err
+		}
+
+		p, err = g.client.Create(prefixPath, nil, zk.FlagSequence)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// the sequence number is kept by the parent, the node is useless after created
+	if err := g.client.Delete(p); err != nil {
+		g.client.log().Warn("zk failed to delete sequence node", "path", p, "error", err)
+	}
+
+	seq, ok := ParseSequence(p)
+	if !ok {
+		return errInvalidValue
+	}
+
+	g.next = seq*g.batchSize + 1
+	g.limit = g.next + g.batchSize
+
+	return nil
+}
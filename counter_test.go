@@ -0,0 +1,72 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicCounter(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/counter"
+	_ = testClient.Delete(path)
+
+	c := testClient.NewAtomicCounter(path)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := c.Increment()
+			assert.Nil(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	value, err := c.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), value)
+
+	ok, err := c.CompareAndSet(9, 100)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	ok, err = c.CompareAndSet(10, 100)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	assert.Nil(t, testClient.Delete(path))
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	g, err := testClient.NewSequenceGenerator("/test/sequence", 5)
+	assert.Nil(t, err)
+
+	last := int64(0)
+
+	for i := 0; i < 12; i++ {
+		id, err := g.Next()
+		assert.Nil(t, err)
+		assert.True(t, id > last)
+
+		last = id
+	}
+}
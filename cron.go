@@ -0,0 +1,211 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMaxYears stop searching the next fire time after years
+const cronMaxYears = 5
+
+// cronField bounds of cron field
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 6},
+}
+
+// Schedule compute the next fire time after the given time, zero time returned if no more fire time
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// cronSchedule standard 5 fields cron schedule: minute hour day-of-month month day-of-week
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// everySchedule fire at every interval, aligned to multiples of the interval so that all instances agree on fire times
+type everySchedule struct {
+	interval time.Duration
+}
+
+// ParseSchedule parse cron spec of 5 fields (`*`, `a`, `a-b`, `*/n`, `a/n`, `a-b/n` and lists supported),
+// or `@every <duration>`, or one of `@hourly`, `@daily`, `@weekly`, `@monthly`.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return nil, err
+		}
+
+		if interval < time.Second {
+			return nil, fmt.Errorf("interval should not be less than 1s: %s", spec)
+		}
+
+		return &everySchedule{interval: interval}, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields: %s", len(cronFields), spec)
+	}
+
+	bits := make([]uint64, len(fields))
+
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+
+		bits[i] = b
+	}
+
+	return &cronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		start, end, step, err := parseCronRange(part, bounds)
+		if err != nil {
+			return 0, err
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// parseCronRange parse range and step of a part of cron field
+func parseCronRange(part string, bounds cronField) (start, end, step int, err error) {
+	invalid := fmt.Errorf("invalid %s: %s", bounds.name, part)
+	rangePart, step := part, 1
+
+	if idx := strings.Index(part, "/"); idx >= 0 {
+		if step, err = strconv.Atoi(part[idx+1:]); err != nil || step <= 0 {
+			return 0, 0, 0, invalid
+		}
+
+		rangePart = part[:idx]
+	}
+
+	start, end = bounds.min, bounds.max
+
+	if rangePart != "*" {
+		values := strings.SplitN(rangePart, "-", 2)
+
+		if start, err = strconv.Atoi(values[0]); err != nil {
+			return 0, 0, 0, invalid
+		}
+
+		switch {
+		case len(values) == 2:
+			if end, err = strconv.Atoi(values[1]); err != nil {
+				return 0, 0, 0, invalid
+			}
+		case step == 1:
+			end = start
+		}
+	}
+
+	if start < bounds.min || end > bounds.max || start > end {
+		return 0, 0, 0, fmt.Errorf("%s out of range [%d, %d]: %s", bounds.name, bounds.min, bounds.max, part)
+	}
+
+	return start, end, step, nil
+}
+
+// Next fire time after t, searched minute by minute from the month down
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronMaxYears, 0, 0)
+
+	for t.Before(limit) {
+		if !hasBit(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !hasBit(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !hasBit(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches match day of month and day of week, either matches if both are restricted
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := hasBit(s.dom, t.Day())
+	dowMatch := hasBit(s.dow, int(t.Weekday()))
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+func hasBit(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// Next fire time after t
+func (s *everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.interval).Add(s.interval)
+}
@@ -0,0 +1,80 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	base := time.Date(2019, 10, 18, 10, 30, 15, 0, time.UTC)
+
+	cases := map[string]time.Time{
+		"* * * * *":      time.Date(2019, 10, 18, 10, 31, 0, 0, time.UTC),
+		"*/15 * * * *":   time.Date(2019, 10, 18, 10, 45, 0, 0, time.UTC),
+		"5/20 * * * *":   time.Date(2019, 10, 18, 10, 45, 0, 0, time.UTC),
+		"0 9-17 * * *":   time.Date(2019, 10, 18, 11, 0, 0, 0, time.UTC),
+		"0 0 1 * *":      time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+		"30 8 * * 1,3":   time.Date(2019, 10, 21, 8, 30, 0, 0, time.UTC),
+		"0 0 1 1 *":      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		"0 12 13 * 5":    time.Date(2019, 10, 18, 12, 0, 0, 0, time.UTC),
+		"@daily":         time.Date(2019, 10, 19, 0, 0, 0, 0, time.UTC),
+		"@every 10m":     time.Date(2019, 10, 18, 10, 40, 0, 0, time.UTC),
+		"0 0 29 2 *":     time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		"0,30 10 18 * *": time.Date(2019, 11, 18, 10, 0, 0, 0, time.UTC),
+	}
+
+	for spec, expected := range cases {
+		s, err := ParseSchedule(spec)
+		assert.Nil(t, err, spec)
+		assert.Equal(t, expected, s.Next(base), spec)
+	}
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "@every 1ms"} {
+		_, err := ParseSchedule(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/scheduler"
+	runs := make(chan string, 10)
+
+	s1 := testClient.NewScheduler(path, "r1")
+	s2 := testClient.NewScheduler(path, "r2")
+
+	for _, s := range []*Scheduler{s1, s2} {
+		s := s
+		err := s.Register("job", "@every 1s", func(ctx context.Context) error {
+			runs <- s.runner
+			return nil
+		})
+		assert.Nil(t, err)
+	}
+
+	waitEventWatch()
+	s1.Stop()
+	s2.Stop()
+
+	fires := len(runs)
+	assert.True(t, fires >= 1 && fires <= 3)
+
+	history, err := s1.History("job")
+	assert.Nil(t, err)
+	assert.True(t, len(history) >= fires)
+
+	last, err := s1.LastRun("job")
+	assert.Nil(t, err)
+	assert.Equal(t, JobSuccess, last.Status)
+}
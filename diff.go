@@ -0,0 +1,474 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	diffPreviewSize = 256
+
+	applyBatchSize  = 100
+	applyBatchBytes = 512 * 1024
+)
+
+// ChangeType type of tree change
+type ChangeType string
+
+const (
+	// ChangeAdd node to be created
+	ChangeAdd ChangeType = "add"
+	// ChangeUpdate value to be updated
+	ChangeUpdate ChangeType = "update"
+	// ChangeRemove node to be deleted
+	ChangeRemove ChangeType = "remove"
+)
+
+// TreeChange change of a node between the live tree and the desired tree
+type TreeChange struct {
+	Type ChangeType `json:"type" yaml:"type"`
+	Path string     `json:"path" yaml:"path"`
+	// Version live version checked when applying, -1 for added node
+	Version int32 `json:"version" yaml:"version"`
+	// Old preview of the live value, raw or decoded by the codec of the diff
+	Old string `json:"old,omitempty" yaml:"old,omitempty"`
+	// New preview of the desired value, raw or decoded by the codec of the diff
+	New string `json:"new,omitempty" yaml:"new,omitempty"`
+
+	data    []byte
+	chunked bool
+	chunks  []string
+}
+
+// TreeDiff changes to make the live tree of the path same as the desired tree,
+// added nodes are ordered before their children, and removed nodes after their children.
+type TreeDiff struct {
+	Path    string        `json:"path" yaml:"path"`
+	Changes []*TreeChange `json:"changes" yaml:"changes"`
+
+	codec Codec
+}
+
+// Empty whether no changes
+func (d *TreeDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// ConflictError the live node was changed by others after the diff computed
+type ConflictError struct {
+	Path string
+	Err  error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflict on %s: %v", e.Path, e.Err)
+}
+
+// Unwrap the cause
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// preview of the value, decoded by the codec and printed as json if possible,
+// otherwise the raw value base64 encoded if not valid utf8, and truncated if too long
+func (d *TreeDiff) preview(data []byte) string {
+	s, ok := decodePreview(d.codec, data)
+	if !ok {
+		s = string(data)
+		if !utf8.Valid(data) {
+			s = EncodingBase64 + ":" + base64.StdEncoding.EncodeToString(data)
+		}
+	}
+
+	if len(s) <= diffPreviewSize {
+		return s
+	}
+
+	end := diffPreviewSize
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+
+	return s[:end] + "..."
+}
+
+// decodePreview decode the value by the codec and print it as json
+func decodePreview(codec Codec, data []byte) (string, bool) {
+	if codec == nil || len(data) == 0 {
+		return "", false
+	}
+
+	v, err := codec.Decode(data)
+	if err != nil {
+		return "", false
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+
+	return string(b), true
+}
+
+// Diff compute changes from the live tree of the path to the desired tree,
+// live nodes not in the desired tree are removed only if prune is true.
+// Ephemeral nodes and their ancestors are never removed.
+// Previews of the changes are raw values, see DiffCodec for decoded previews.
+func (cli *Client) Diff(path string, desired *TreeNode, prune bool) (*TreeDiff, error) {
+	return cli.DiffCodec(path, desired, prune, nil)
+}
+
+// DiffCodec compute changes same as Diff, with previews decoded by the codec,
+// raw previews for values failed to decode.
+func (cli *Client) DiffCodec(path string, desired *TreeNode, prune bool, codec Codec) (*TreeDiff, error) {
+	if desired == nil {
+		return nil, errInvalidValue
+	}
+
+	diff := &TreeDiff{Path: path, Changes: []*TreeChange{}, codec: codec}
+
+	if err := cli.diffNode(diff, path, desired, prune); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+func (cli *Client) diffNode(diff *TreeDiff, path string, node *TreeNode, prune bool) error {
+	data, err := node.Data()
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	raw, stat, err := cli.conn.Get(path)
+	if err == zk.ErrNoNode {
+		return diffAdd(diff, path, node, data)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, chunked := parseChunkManifest(raw)
+
+	current, err := cli.resolveRawValue(path, raw)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(current, data) {
+		diff.Changes = append(diff.Changes, &TreeChange{
+			Type:    ChangeUpdate,
+			Path:    path,
+			Version: stat.Version,
+			Old:     diff.preview(current),
+			New:     diff.preview(data),
+			data:    data,
+			chunked: chunked,
+		})
+	}
+
+	children, err := cli.valueChildren(path)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range node.sortedChildren() {
+		if err := cli.diffNode(diff, childNodePath(path, name), node.Children[name], prune); err != nil {
+			return err
+		}
+	}
+
+	if !prune {
+		return nil
+	}
+
+	for _, name := range children {
+		if _, ok := node.Children[name]; !ok {
+			if _, err := cli.diffRemove(diff, childNodePath(path, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffAdd add changes to create the node and its children
+func diffAdd(diff *TreeDiff, path string, node *TreeNode, data []byte) error {
+	diff.Changes = append(diff.Changes, &TreeChange{
+		Type:    ChangeAdd,
+		Path:    path,
+		Version: -1,
+		New:     diff.preview(data),
+		data:    data,
+	})
+
+	for _, name := range node.sortedChildren() {
+		child := node.Children[name]
+		childPath := childNodePath(path, name)
+
+		childData, err := child.Data()
+		if err != nil {
+			return fmt.Errorf("%s: %w", childPath, err)
+		}
+
+		if err := diffAdd(diff, childPath, child, childData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffRemove add changes to delete the subtree of the path, false returned if the path is kept for ephemeral nodes
+func (cli *Client) diffRemove(diff *TreeDiff, path string) (bool, error) {
+	raw, stat, err := cli.conn.Get(path)
+	if err == zk.ErrNoNode {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if stat.EphemeralOwner != 0 {
+		return false, nil
+	}
+
+	children, _, err := cli.conn.Children(path)
+	if err != nil {
+		return false, err
+	}
+
+	var chunks []string
+
+	deletable := true
+
+	for _, child := range children {
+		if isChunkNode(child) {
+			chunks = append(chunks, child)
+			continue
+		}
+
+		deleted, err := cli.diffRemove(diff, childNodePath(path, child))
+		if err != nil {
+			return false, err
+		}
+
+		deletable = deletable && deleted
+	}
+
+	if !deletable {
+		cli.log().Warn("zk diff keep node having ephemeral nodes", "path", path)
+		return false, nil
+	}
+
+	current, err := cli.resolveRawValue(path, raw)
+	if err != nil {
+		current = raw
+	}
+
+	diff.Changes = append(diff.Changes, &TreeChange{
+		Type:    ChangeRemove,
+		Path:    path,
+		Version: stat.Version,
+		Old:     diff.preview(current),
+		chunks:  chunks,
+	})
+
+	return true, nil
+}
+
+// ApplyDiff apply changes in batched transactions with version checks,
+// a ConflictError returned if any node was changed by others after the diff computed.
+// Batches applied before the conflict are not rolled back.
+// The diff must be computed by Diff, since the desired values are not serialized.
+func (cli *Client) ApplyDiff(diff *TreeDiff) error {
+	return cli.ApplyDiffContext(context.Background(), diff)
+}
+
+// ApplyDiffContext apply changes, the ctx carries the actor of audit records, see ApplyDiff
+func (cli *Client) ApplyDiffContext(ctx context.Context, diff *TreeDiff) error {
+	for _, c := range diff.Changes {
+		if err := cli.checkChange(c); err != nil {
+			return err
+		}
+	}
+
+	if parent := ParentNode(diff.Path); parent != "" {
+		if err := cli.EnsurePathContext(ctx, parent); err != nil {
+			return err
+		}
+	}
+
+	var (
+		batch   []interface{}
+		paths   []string
+		size    int
+		chunked []*TreeChange
+		applied []*TreeChange
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := cli.applyBatch(batch, paths)
+		batch, paths, size = nil, nil, 0
+
+		for _, c := range applied {
+			cli.auditChange(ctx, c, c.Version+1, err)
+		}
+
+		applied = nil
+
+		return err
+	}
+
+	add := func(op interface{}, path string, dataSize int) error {
+		batch = append(batch, op)
+		paths = append(paths, path)
+		size += dataSize
+
+		if len(batch) >= applyBatchSize || size >= applyBatchBytes {
+			return flush()
+		}
+
+		return nil
+	}
+
+	for _, c := range diff.Changes {
+		var err error
+
+		large := cli.chunkSize > 0 && len(c.data) > cli.chunkSize
+
+		switch c.Type {
+		case ChangeAdd:
+			data := c.data
+			if large {
+				data = nil
+
+				chunked = append(chunked, c)
+			}
+
+			err = add(&zk.CreateRequest{Path: c.Path, Data: data, Acl: zk.WorldACL(zk.PermAll)}, c.Path, len(data))
+		case ChangeUpdate:
+			if large || c.chunked {
+				chunked = append(chunked, c)
+				continue
+			}
+
+			err = add(&zk.SetDataRequest{Path: c.Path, Data: c.data, Version: c.Version}, c.Path, len(c.data))
+		case ChangeRemove:
+			for _, chunk := range c.chunks {
+				if err = add(&zk.DeleteRequest{Path: PathJoin(c.Path, chunk), Version: -1}, c.Path, 0); err != nil {
+					return err
+				}
+			}
+
+			err = add(&zk.DeleteRequest{Path: c.Path, Version: c.Version}, c.Path, 0)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !large {
+			applied = append(applied, c)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	for _, c := range chunked {
+		version := c.Version
+		if c.Type == ChangeAdd {
+			version = 0
+		}
+
+		newVersion, err := cli.setChunkedValue(c.Path, c.data, version)
+		cli.auditChange(ctx, c, newVersion, err)
+
+		if err != nil {
+			return conflictError(c.Path, err)
+		}
+	}
+
+	cli.log().Info("zk applied changes", "path", diff.Path, "changes", len(diff.Changes))
+
+	return nil
+}
+
+// Reconcile compute the diff of the path to the desired tree and apply it, see Diff and ApplyDiff
+func (cli *Client) Reconcile(path string, desired *TreeNode, prune bool) (*TreeDiff, error) {
+	diff, err := cli.Diff(path, desired, prune)
+	if err != nil {
+		return nil, err
+	}
+
+	if diff.Empty() {
+		return diff, nil
+	}
+
+	return diff, cli.ApplyDiff(diff)
+}
+
+// checkChange check the change by the write policy
+func (cli *Client) checkChange(c *TreeChange) error {
+	if c.Type == ChangeRemove {
+		return cli.checkDelete(c.Path, false)
+	}
+
+	return cli.checkWrite(c.Path, c.data)
+}
+
+// auditChange audit the change applied, or failed with the err
+func (cli *Client) auditChange(ctx context.Context, c *TreeChange, newVersion int32, err error) {
+	switch c.Type {
+	case ChangeAdd:
+		cli.audit(ctx, OpCreate, c.Path, noVersion, newVersion, c.data, err)
+	case ChangeUpdate:
+		cli.audit(ctx, OpSet, c.Path, c.Version, newVersion, c.data, err)
+	case ChangeRemove:
+		cli.audit(ctx, OpDelete, c.Path, c.Version, noVersion, nil, err)
+	}
+}
+
+// applyBatch apply the operations in one transaction, the error of the failed operation is returned
+func (cli *Client) applyBatch(ops []interface{}, paths []string) error {
+	responses, err := cli.conn.Multi(ops...)
+
+	for i, res := range responses {
+		if res.Error != nil {
+			return conflictError(paths[i], res.Error)
+		}
+	}
+
+	return err
+}
+
+// conflictError wrap errors of version checks as ConflictError
+func conflictError(path string, err error) error {
+	switch err {
+	case zk.ErrNodeExists, zk.ErrBadVersion, zk.ErrNoNode, zk.ErrNotEmpty:
+		return &ConflictError{Path: path, Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreview(t *testing.T) {
+	raw := &TreeDiff{}
+	assert.Equal(t, "abc", raw.preview([]byte("abc")))
+	assert.Equal(t, "base64://4=", raw.preview([]byte{0xff, 0xfe}))
+
+	long := raw.preview([]byte(strings.Repeat("中", diffPreviewSize)))
+	assert.True(t, strings.HasSuffix(long, "..."))
+	assert.True(t, len(long) <= diffPreviewSize+3)
+	assert.Equal(t, strings.Repeat("中", diffPreviewSize/3)+"...", long)
+
+	decoded := &TreeDiff{codec: &JSONCodec{typ: reflect.TypeOf(user{})}}
+	assert.Equal(t, `{"Name":"wongoo","Sex":1}`, decoded.preview([]byte(`{"Sex": 1, "Name": "wongoo"}`)))
+
+	// raw preview if failed to decode
+	assert.Equal(t, "abc", decoded.preview([]byte("abc")))
+}
+
+func TestReconcile(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	_ = testClient.DeleteRecursive("/test/reconcile")
+	defer func() { _ = testClient.DeleteRecursive("/test/reconcile") }()
+
+	assert.Nil(t, testClient.SetString("/test/reconcile/a", "1"))
+	assert.Nil(t, testClient.SetString("/test/reconcile/old", "x"))
+
+	desired := &TreeNode{Children: map[string]*TreeNode{
+		"a": {Value: "2"},
+		"b": {Value: "3", Children: map[string]*TreeNode{"c": {Value: "4"}}},
+	}}
+
+	diff, err := testClient.Diff("/test/reconcile", desired, true)
+	assert.Nil(t, err)
+
+	var changes []string
+	for _, c := range diff.Changes {
+		changes = append(changes, string(c.Type)+" "+c.Path+" "+c.New)
+	}
+
+	assert.Equal(t, []string{
+		"update /test/reconcile/a 2",
+		"add /test/reconcile/b 3",
+		"add /test/reconcile/b/c 4",
+		"remove /test/reconcile/old ",
+	}, changes)
+	assert.Equal(t, "1", diff.Changes[0].Old)
+
+	// concurrent manual edit
+	assert.Nil(t, testClient.SetString("/test/reconcile/a", "manual"))
+
+	err = testClient.ApplyDiff(diff)
+
+	var conflict *ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "/test/reconcile/a", conflict.Path)
+	assert.Equal(t, zk.ErrBadVersion, conflict.Err)
+
+	s, _ := testClient.GetString("/test/reconcile/a")
+	assert.Equal(t, "manual", s)
+
+	diff, err = testClient.Reconcile("/test/reconcile", desired, true)
+	assert.Nil(t, err)
+	assert.Len(t, diff.Changes, 4)
+
+	diff, err = testClient.Diff("/test/reconcile", desired, true)
+	assert.Nil(t, err)
+	assert.True(t, diff.Empty())
+}
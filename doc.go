@@ -17,6 +17,7 @@
 //- `Watcher`: loop watch control
 //- `Handler`: include `valueHandler` and `mapHandler`, set/get/delete value, handle event, synchronize value, trigger listener
 //- `Listener`:  include `ValueListener` and `ChildListener`,  listen value updated/deleted
+//- `ChangeListener`:  include `ValueChangeListener` and `ChildChangeListener`,  listen value changes with the previous value
 //
 //## API
 //
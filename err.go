@@ -8,5 +8,6 @@ package zkclient
 import "errors"
 
 var (
-	errInvalidValue = errors.New("invalid value")
+	errInvalidValue  = errors.New("invalid value")
+	errChunkChecksum = errors.New("chunk checksum mismatch")
 )
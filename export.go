@@ -0,0 +1,245 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	// EncodingBase64 encoding of node value which is not valid utf8
+	EncodingBase64 = "base64"
+)
+
+// ImportMode mode of importing tree document
+type ImportMode int
+
+const (
+	// ImportMerge create missing nodes and update changed values, keep nodes not in the document
+	ImportMerge ImportMode = 0
+	// ImportOverwrite like merge, and delete nodes not in the document
+	ImportOverwrite ImportMode = 1 << 0
+	// ImportDryRun compute changes without applying, can be combined with other modes
+	ImportDryRun ImportMode = 1 << 1
+)
+
+// NodeStat portable view of zk.Stat
+type NodeStat struct {
+	Czxid          int64 `json:"czxid" yaml:"czxid"`
+	Mzxid          int64 `json:"mzxid" yaml:"mzxid"`
+	Pzxid          int64 `json:"pzxid" yaml:"pzxid"`
+	Ctime          int64 `json:"ctime" yaml:"ctime"`
+	Mtime          int64 `json:"mtime" yaml:"mtime"`
+	Version        int32 `json:"version" yaml:"version"`
+	Cversion       int32 `json:"cversion" yaml:"cversion"`
+	Aversion       int32 `json:"aversion" yaml:"aversion"`
+	EphemeralOwner int64 `json:"ephemeralOwner" yaml:"ephemeralOwner"`
+	DataLength     int32 `json:"dataLength" yaml:"dataLength"`
+	NumChildren    int32 `json:"numChildren" yaml:"numChildren"`
+}
+
+// NewNodeStat create portable stat, nil returned for nil stat
+func NewNodeStat(stat *zk.Stat) *NodeStat {
+	if stat == nil {
+		return nil
+	}
+
+	return &NodeStat{
+		Czxid:          stat.Czxid,
+		Mzxid:          stat.Mzxid,
+		Pzxid:          stat.Pzxid,
+		Ctime:          stat.Ctime,
+		Mtime:          stat.Mtime,
+		Version:        stat.Version,
+		Cversion:       stat.Cversion,
+		Aversion:       stat.Aversion,
+		EphemeralOwner: stat.EphemeralOwner,
+		DataLength:     stat.DataLength,
+		NumChildren:    stat.NumChildren,
+	}
+}
+
+// TreeNode node of tree document, the value is base64 encoded if not valid utf8
+type TreeNode struct {
+	Value    string               `json:"value,omitempty" yaml:"value,omitempty"`
+	Encoding string               `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	Stat     *NodeStat            `json:"stat,omitempty" yaml:"stat,omitempty"`
+	Children map[string]*TreeNode `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// NewTreeNode create tree node of the data
+func NewTreeNode(data []byte) *TreeNode {
+	if utf8.Valid(data) {
+		return &TreeNode{Value: string(data)}
+	}
+
+	return &TreeNode{Value: base64.StdEncoding.EncodeToString(data), Encoding: EncodingBase64}
+}
+
+// Data decoded value of the node
+func (n *TreeNode) Data() ([]byte, error) {
+	switch n.Encoding {
+	case "":
+		return []byte(n.Value), nil
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(n.Value)
+	default:
+		return nil, fmt.Errorf("unknown encoding: %s", n.Encoding)
+	}
+}
+
+// sortedChildren names of children in order
+func (n *TreeNode) sortedChildren() []string {
+	names := make([]string, 0, len(n.Children))
+	for name := range n.Children {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// TreeDocument portable document of a subtree
+type TreeDocument struct {
+	Path string    `json:"path" yaml:"path"`
+	Root *TreeNode `json:"root" yaml:"root"`
+}
+
+// ImportResult paths changed by importing
+type ImportResult struct {
+	Created []string `json:"created" yaml:"created"`
+	Updated []string `json:"updated" yaml:"updated"`
+	Deleted []string `json:"deleted" yaml:"deleted"`
+}
+
+// Export the subtree of the path, ephemeral nodes are excluded and chunked values are reassembled
+func (cli *Client) Export(path string, withStat bool) (*TreeDocument, error) {
+	root, err := cli.exportNode(path, withStat)
+	if err != nil {
+		return nil, err
+	}
+
+	if root == nil {
+		return nil, zk.ErrNoNode
+	}
+
+	return &TreeDocument{Path: path, Root: root}, nil
+}
+
+// exportNode export the node and its children, nil returned for ephemeral node
+func (cli *Client) exportNode(path string, withStat bool) (*TreeNode, error) {
+	data, stat, err := cli.getRawValue(context.Background(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	if stat.EphemeralOwner != 0 {
+		return nil, nil
+	}
+
+	node := NewTreeNode(data)
+	if withStat {
+		node.Stat = NewNodeStat(stat)
+	}
+
+	children, err := cli.valueChildren(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		childNode, err := cli.exportNode(childNodePath(path, child), withStat)
+		if err == zk.ErrNoNode {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if childNode != nil {
+			if node.Children == nil {
+				node.Children = make(map[string]*TreeNode)
+			}
+
+			node.Children[child] = childNode
+		}
+	}
+
+	return node, nil
+}
+
+// valueChildren children of the path excluding chunk nodes
+func (cli *Client) valueChildren(path string) ([]string, error) {
+	children, _, err := cli.conn.Children(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := children[:0]
+
+	for _, child := range children {
+		if !isChunkNode(child) {
+			nodes = append(nodes, child)
+		}
+	}
+
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+// childNodePath join child to the parent path, which may be the root
+func childNodePath(parent, child string) string {
+	if parent == PathSplit {
+		return parent + child
+	}
+
+	return PathJoin(parent, child)
+}
+
+// Import the document into the path, changes are computed by Diff and applied by ApplyDiff,
+// live nodes not in the document are deleted only in ImportOverwrite mode.
+func (cli *Client) Import(path string, doc *TreeDocument, mode ImportMode) (*ImportResult, error) {
+	if doc == nil || doc.Root == nil {
+		return nil, errInvalidValue
+	}
+
+	diff, err := cli.Diff(path, doc.Root, mode&ImportOverwrite != 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Created: []string{}, Updated: []string{}, Deleted: []string{}}
+
+	for _, c := range diff.Changes {
+		switch c.Type {
+		case ChangeAdd:
+			result.Created = append(result.Created, c.Path)
+		case ChangeUpdate:
+			result.Updated = append(result.Updated, c.Path)
+		case ChangeRemove:
+			result.Deleted = append(result.Deleted, c.Path)
+		}
+	}
+
+	if mode&ImportDryRun != 0 || diff.Empty() {
+		return result, nil
+	}
+
+	if err = cli.ApplyDiff(diff); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
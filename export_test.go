@@ -0,0 +1,81 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeNodeData(t *testing.T) {
+	node := NewTreeNode([]byte("hello"))
+	assert.Equal(t, "hello", node.Value)
+	assert.Equal(t, "", node.Encoding)
+
+	binary := []byte{0xff, 0xfe, 0x00}
+	node = NewTreeNode(binary)
+	assert.Equal(t, EncodingBase64, node.Encoding)
+
+	data, err := node.Data()
+	assert.Nil(t, err)
+	assert.Equal(t, binary, data)
+
+	node.Encoding = "hex"
+	_, err = node.Data()
+	assert.NotNil(t, err)
+}
+
+func TestExportImport(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	_ = testClient.DeleteRecursive("/test/export")
+	_ = testClient.DeleteRecursive("/test/import")
+
+	defer func() {
+		_ = testClient.DeleteRecursive("/test/export")
+		_ = testClient.DeleteRecursive("/test/import")
+	}()
+
+	assert.Nil(t, testClient.SetString("/test/export/a", "1"))
+	assert.Nil(t, testClient.SetString("/test/export/b/c", "2"))
+	assert.Nil(t, testClient.SetTempString("/test/export/temp", "x"))
+
+	doc, err := testClient.Export("/test/export", false)
+	assert.Nil(t, err)
+	assert.Len(t, doc.Root.Children, 2)
+	assert.Equal(t, "2", doc.Root.Children["b"].Children["c"].Value)
+
+	result, err := testClient.Import("/test/import", doc, ImportDryRun)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/test/import", "/test/import/a", "/test/import/b", "/test/import/b/c"}, result.Created)
+
+	exists, _ := testClient.Exists("/test/import")
+	assert.False(t, exists)
+
+	_, err = testClient.Import("/test/import", doc, ImportMerge)
+	assert.Nil(t, err)
+
+	s, _ := testClient.GetString("/test/import/b/c")
+	assert.Equal(t, "2", s)
+
+	assert.Nil(t, testClient.SetString("/test/import/extra", "3"))
+	doc.Root.Children["a"].Value = "10"
+
+	result, err = testClient.Import("/test/import", doc, ImportMerge)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/test/import/a"}, result.Updated)
+	assert.Empty(t, result.Deleted)
+
+	result, err = testClient.Import("/test/import", doc, ImportOverwrite)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/test/import/extra"}, result.Deleted)
+
+	exists, _ = testClient.Exists("/test/import/extra")
+	assert.False(t, exists)
+}
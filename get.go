@@ -6,13 +6,16 @@
 package zkclient
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
+
+	"github.com/samuel/go-zookeeper/zk"
 )
 
 // Encode value from zookeeper, the raw value will be decoded by codec
 func (cli *Client) Get(path string, codec Codec) (interface{}, error) {
-	data, _, err := cli.Conn().Get(path)
+	data, _, err := cli.getRawValue(context.Background(), path)
 	if err != nil {
 		return nil, err
 	}
@@ -25,9 +28,19 @@ func (cli *Client) Get(path string, codec Codec) (interface{}, error) {
 	return v, nil
 }
 
+// GetRawValue get raw value and stat from zookeeper, chunked value will be reassembled
+func (cli *Client) GetRawValue(path string) ([]byte, *zk.Stat, error) {
+	return cli.getRawValue(context.Background(), path)
+}
+
+// GetRawValueContext get raw value and stat, the operation is traced as a child span of the ctx
+func (cli *Client) GetRawValueContext(ctx context.Context, path string) ([]byte, *zk.Stat, error) {
+	return cli.getRawValue(ctx, path)
+}
+
 // GetString get string value from zookeeper
 func (cli *Client) GetString(path string) (string, error) {
-	data, _, err := cli.Conn().Get(path)
+	data, _, err := cli.getRawValue(context.Background(), path)
 	if err != nil {
 		return "", err
 	}
@@ -37,7 +50,7 @@ func (cli *Client) GetString(path string) (string, error) {
 
 // GetJSON get json value from zookeeper
 func (cli *Client) GetJSON(path string, typ reflect.Type) (interface{}, error) {
-	data, _, err := cli.Conn().Get(path)
+	data, _, err := cli.getRawValue(context.Background(), path)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +62,7 @@ func (cli *Client) GetJSON(path string, typ reflect.Type) (interface{}, error) {
 
 // ParseJSON parse json value from zookeeper into target object
 func (cli *Client) ParseJSON(path string, target interface{}) error {
-	data, _, err := cli.Conn().Get(path)
+	data, _, err := cli.getRawValue(context.Background(), path)
 	if err != nil {
 		return err
 	}
@@ -59,12 +72,38 @@ func (cli *Client) ParseJSON(path string, target interface{}) error {
 
 // GetChildren get child nodes
 func (cli *Client) GetChildren(path string) ([]string, error) {
-	children, _, err := cli.Conn().Children(path)
+	return cli.GetChildrenContext(context.Background(), path)
+}
+
+// GetChildrenContext get child nodes, the operation is traced as a child span of the ctx
+func (cli *Client) GetChildrenContext(ctx context.Context, path string) ([]string, error) {
+	_, o := cli.startOperation(ctx, OpChildren, path)
+	children, stat, err := cli.Conn().Children(path)
+
+	if err == nil {
+		o.version(stat.Cversion)
+	}
+
+	o.end(err)
+
 	return children, err
 }
 
 // Exists check node exists
 func (cli *Client) Exists(path string) (bool, error) {
-	exists, _, err := cli.Conn().Exists(path)
+	return cli.ExistsContext(context.Background(), path)
+}
+
+// ExistsContext check node exists, the operation is traced as a child span of the ctx
+func (cli *Client) ExistsContext(ctx context.Context, path string) (bool, error) {
+	_, o := cli.startOperation(ctx, OpExists, path)
+	exists, stat, err := cli.Conn().Exists(path)
+
+	if exists {
+		o.version(stat.Version)
+	}
+
+	o.end(err)
+
 	return exists, err
 }
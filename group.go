@@ -0,0 +1,171 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// GroupListener listen members joining, updating metadata and leaving the group
+type GroupListener interface {
+	Join(path, member string, metadata interface{})
+	Update(path, member string, metadata interface{})
+	Leave(path, member string, metadata interface{})
+}
+
+// GroupMember member of a group, which is an ephemeral node carrying the member metadata under the group path.
+// The node is re-created after reconnection, and members of the group are synchronized into a map.
+type GroupMember struct {
+	lock          sync.Mutex
+	client        *Client
+	path          string
+	id            string
+	nodePath      string
+	codec         Codec
+	data          []byte
+	memberWatcher *Watcher
+	groupWatcher  *Watcher
+}
+
+// JoinGroup join the group of the path as member id, and synchronize members metadata into the map
+func (cli *Client) JoinGroup(path, id string, metadata interface{}, codec Codec,
+	members interface{}, listener GroupListener, options ...SyncOption) (*GroupMember, error) {
+	if path == "" || id == "" {
+		return nil, errors.New("path and id required")
+	}
+
+	data, err := codec.Encode(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &GroupMember{
+		client:   cli,
+		path:     path,
+		id:       id,
+		nodePath: PathJoin(path, id),
+		codec:    codec,
+		data:     data,
+	}
+
+	if listener != nil {
+		options = append(options, WithChildChangeListener(&groupChangeListener{listener: listener}))
+	}
+
+	if m.groupWatcher, err = cli.SyncWatchMap(path, members, codec, true, nil, options...); err != nil {
+		return nil, err
+	}
+
+	if m.memberWatcher, err = cli.createWatcher(&groupMemberHandler{member: m}); err != nil {
+		m.groupWatcher.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ID of the member
+func (m *GroupMember) ID() string {
+	return m.id
+}
+
+// Path of the group
+func (m *GroupMember) Path() string {
+	return m.path
+}
+
+// UpdateMetadata update the metadata of the member
+func (m *GroupMember) UpdateMetadata(metadata interface{}) error {
+	data, err := m.codec.Encode(metadata)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	m.data = data
+	m.lock.Unlock()
+
+	return m.client.SetTempRawValue(m.nodePath, data)
+}
+
+// Leave the group, stop synchronizing members
+func (m *GroupMember) Leave() error {
+	m.memberWatcher.Close()
+	m.groupWatcher.Close()
+
+	return m.client.Delete(m.nodePath)
+}
+
+func (m *GroupMember) metadata() []byte {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.data
+}
+
+// groupMemberHandler keep the ephemeral member node alive
+type groupMemberHandler struct {
+	member *GroupMember
+}
+
+func (h *groupMemberHandler) Path() string {
+	return h.member.nodePath
+}
+
+func (h *groupMemberHandler) Handle(w *Watcher, _ *zk.Event) (<-chan zk.Event, error) {
+	m := h.member
+
+	for {
+		_, err := w.client.Create(m.nodePath, m.metadata(), zk.FlagEphemeral)
+		if err == zk.ErrNoNode {
+			if err = w.client.EnsurePath(m.path); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if err != nil && err != zk.ErrNodeExists {
+			return nil, err
+		}
+
+		exists, stat, ch, err := w.client.Conn().ExistsW(m.nodePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			continue
+		}
+
+		if stat.EphemeralOwner != w.client.Conn().SessionID() {
+			w.client.log().Warn("zk group member owned by other session, wait for it expired",
+				"path", m.nodePath, "owner", stat.EphemeralOwner, "session", w.client.SessionID())
+		}
+
+		return ch, nil
+	}
+}
+
+// groupChangeListener adapt child change events to group events
+type groupChangeListener struct {
+	listener GroupListener
+}
+
+func (l *groupChangeListener) Change(path, child string, _, _ *zk.Stat, oldObj, newObj interface{}) {
+	if oldObj == nil {
+		l.listener.Join(path, child, newObj)
+	} else {
+		l.listener.Update(path, child, newObj)
+	}
+}
+
+func (l *groupChangeListener) Remove(path, child string, _ *zk.Stat, lastObj interface{}) {
+	l.listener.Leave(path, child, lastObj)
+}
@@ -0,0 +1,67 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type groupEvents struct {
+	joined, updated, left []string
+}
+
+func (g *groupEvents) Join(_, member string, _ interface{}) {
+	g.joined = append(g.joined, member)
+}
+
+func (g *groupEvents) Update(_, member string, _ interface{}) {
+	g.updated = append(g.updated, member)
+}
+
+func (g *groupEvents) Leave(_, member string, _ interface{}) {
+	g.left = append(g.left, member)
+}
+
+func TestGroupMember(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/group"
+	members := make(map[string]*user)
+	events := &groupEvents{}
+
+	m1, err := testClient.JoinGroup(path, "m1", &user{Name: "wongoo"}, &JSONCodec{}, members, events)
+	assert.Nil(t, err)
+
+	others := make(map[string]*user)
+	m2, err := testClient.JoinGroup(path, "m2", &user{Name: "jack"}, &JSONCodec{}, others, nil)
+	assert.Nil(t, err)
+
+	waitEventWatch()
+
+	assert.Equal(t, 2, len(members))
+	assert.Equal(t, "jack", members["m2"].Name)
+	assert.ElementsMatch(t, []string{"m1", "m2"}, events.joined)
+
+	assert.Nil(t, m2.UpdateMetadata(&user{Name: "yang"}))
+
+	waitEventWatch()
+
+	assert.Equal(t, "yang", members["m2"].Name)
+	assert.Equal(t, []string{"m2"}, events.updated)
+
+	assert.Nil(t, m2.Leave())
+
+	waitEventWatch()
+
+	assert.Equal(t, 1, len(members))
+	assert.Equal(t, []string{"m2"}, events.left)
+
+	assert.Nil(t, m1.Leave())
+}
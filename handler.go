@@ -7,24 +7,24 @@ package zkclient
 
 import (
 	"errors"
-	"io"
 	"reflect"
 
 	"github.com/samuel/go-zookeeper/zk"
-	"github.com/vogo/logger"
 )
 
 type valueHandler struct {
+	*SyncOptions
 	path        string
 	value       reflect.Value
 	codec       Codec
 	listenAsync bool
 	listener    ValueListener
+	last        *ChildEvent
 }
 
 func (cli *Client) newValueHandler(path string, obj interface{}, codec Codec,
 	watchOnly bool,
-	listener ValueListener) (*valueHandler, error) {
+	listener ValueListener, options []SyncOption) (*valueHandler, error) {
 	if path == "" {
 		return nil, errors.New("path required")
 	}
@@ -42,16 +42,18 @@ func (cli *Client) newValueHandler(path string, obj interface{}, codec Codec,
 		return nil, errors.New("codec required")
 	}
 
-	if watchOnly && listener == nil {
+	syncOptions := newSyncOptions(options)
+	syncOptions.metrics = cli.metrics
+
+	if watchOnly && listener == nil && syncOptions.valueChangeListener == nil {
 		return nil, errors.New("listener required when watch only")
 	}
 
 	// set json value type
-	if jsonCodec, ok := codec.(*JSONCodec); ok {
-		jsonCodec.typ = typ.Elem()
-	}
+	setJSONCodecType(codec, typ.Elem())
 
 	handler := &valueHandler{
+		SyncOptions: syncOptions,
 		path:        path,
 		codec:       codec,
 		listenAsync: cli.listenAsync,
@@ -79,25 +81,62 @@ func (h *valueHandler) Decode(stat *zk.Stat, data []byte) error {
 		return err
 	}
 
+	if err = h.validate(h.path, v); err != nil {
+		return err
+	}
+
 	if h.value != nilValue {
 		h.value.Elem().Set(reflect.ValueOf(v).Elem())
 	}
 
 	if h.listener != nil {
-		f := func() {
+		h.listen(h.listenAsync, h.path, func() {
 			h.listener.Update(h.path, stat, h.value.Interface())
-		}
+		})
+	}
 
-		if h.listenAsync {
-			go f()
-		} else {
-			f()
+	if h.valueChangeListener != nil {
+		last := h.last
+		if last == nil {
+			last = &ChildEvent{}
 		}
+
+		h.last = &ChildEvent{Stat: stat, Value: v}
+
+		h.listen(h.listenAsync, h.path, func() {
+			h.valueChangeListener.Change(h.path, last.Stat, stat, last.Value, v)
+		})
 	}
 
 	return nil
 }
 
+// remove clear the last value, and trigger listeners
+func (h *valueHandler) remove() {
+	if h.listener != nil {
+		h.listen(h.listenAsync, h.path, func() {
+			h.listener.Delete(h.path)
+		})
+	}
+
+	if last := h.last; last != nil {
+		h.last = nil
+
+		h.listen(h.listenAsync, h.path, func() {
+			h.valueChangeListener.Remove(h.path, last.Stat, last.Value)
+		})
+	}
+}
+
+// callListener call the listener function, asynchronously if required
+func callListener(async bool, f func()) {
+	if async {
+		go f()
+	} else {
+		f()
+	}
+}
+
 // SetTo set value in zookeeper
 func (h *valueHandler) SetTo(cli *Client, path string) error {
 	bytes, err := h.Encode()
@@ -114,11 +153,9 @@ func (h *valueHandler) Path() string {
 
 func (h *valueHandler) Handle(w *Watcher, evt *zk.Event) (<-chan zk.Event, error) {
 	if evt != nil && evt.Type == zk.EventNodeDeleted {
-		logger.Infof("zk watcher [%s] node deleted", h.path)
+		w.client.log().Info("zk watcher node deleted", "path", h.path)
 
-		if h.listener != nil {
-			h.listener.Delete(h.path)
-		}
+		h.remove()
 
 		return nil, nil
 	}
@@ -148,15 +185,18 @@ func (h *valueHandler) Handle(w *Watcher, evt *zk.Event) (<-chan zk.Event, error
 		return wch, nil
 	}
 
-	if err := h.Decode(stat, data); err != nil {
-		if err == io.EOF {
-			return wch, nil // ignore nil data
-		}
-
-		logger.Warnf("zk failed to parse %s: %v", h.path, err)
+	// wait for the next complete version if failed to reassemble chunks
+	if data, err = w.client.resolveRawValue(h.path, data); err != nil {
+		w.client.log().Warn("zk failed to load chunks", "path", h.path, "error", err)
+		return wch, nil
+	}
 
+	if err := h.Decode(stat, data); err != nil {
+		h.reportError(w.client, h.path, err)
 		return wch, nil
 	}
 
+	w.client.writeBackMigration(h.path, stat, data, h.codec)
+
 	return wch, nil
 }
@@ -0,0 +1,117 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// WatcherStatus status of a watcher for health checking
+type WatcherStatus struct {
+	Path string
+
+	// Alive false if the watcher is queued to watch again
+	Alive bool
+
+	// LastHandled last time the path was loaded and watched successfully
+	LastHandled time.Time
+
+	// LastError last error of loading and watching the path
+	LastError     error
+	LastErrorTime time.Time
+}
+
+// State of the connection
+func (cli *Client) State() zk.State {
+	if cli.conn == nil {
+		return zk.StateDisconnected
+	}
+
+	return cli.conn.State()
+}
+
+// LastSuccess last time an operation or a watch completed successfully or answered by the server, zero if none
+func (cli *Client) LastSuccess() time.Time {
+	nano := atomic.LoadInt64(&cli.lastSuccess)
+	if nano == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nano)
+}
+
+// succeeded record the last success if no error, or the err is a result answered by the server
+func (cli *Client) succeeded(err error) {
+	switch err {
+	case nil, zk.ErrNoNode, zk.ErrNodeExists, zk.ErrBadVersion:
+		atomic.StoreInt64(&cli.lastSuccess, time.Now().UnixNano())
+	}
+}
+
+// WatcherStatuses statuses of alive watchers and dead watchers queued to watch again, sorted by path
+func (cli *Client) WatcherStatuses() []WatcherStatus {
+	cli.Lock()
+
+	watchers := make([]*Watcher, 0, len(cli.watchers)+len(cli.deadWatchers))
+	for w := range cli.watchers {
+		watchers = append(watchers, w)
+	}
+
+	for _, w := range cli.deadWatchers {
+		if _, ok := cli.watchers[w]; !ok {
+			watchers = append(watchers, w)
+		}
+	}
+
+	cli.Unlock()
+
+	statuses := make([]WatcherStatus, 0, len(watchers))
+
+	for _, w := range watchers {
+		w.Lock()
+		statuses = append(statuses, WatcherStatus{
+			Path:          w.handler.Path(),
+			Alive:         w.Alive(),
+			LastHandled:   w.lastHandled,
+			LastError:     w.lastErr,
+			LastErrorTime: w.lastErrTime,
+		})
+		w.Unlock()
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Path < statuses[j].Path
+	})
+
+	return statuses
+}
+
+// register the watching watcher
+func (cli *Client) register(w *Watcher) {
+	cli.Lock()
+
+	if cli.watchers == nil {
+		cli.watchers = make(map[*Watcher]struct{})
+	}
+
+	cli.watchers[w] = nilStruct
+	cli.Unlock()
+
+	cli.reportWatchers()
+}
+
+// unregister the exited watcher
+func (cli *Client) unregister(w *Watcher) {
+	cli.Lock()
+	delete(cli.watchers, w)
+	cli.Unlock()
+
+	cli.reportWatchers()
+}
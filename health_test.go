@@ -0,0 +1,59 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcherStatuses(t *testing.T) {
+	cli := &Client{done: make(chan struct{})}
+	assert.Equal(t, zk.StateDisconnected, cli.State())
+	assert.True(t, cli.LastSuccess().IsZero())
+
+	failed := &Watcher{client: cli, handler: &errorHandler{path: "/b", err: zk.ErrNoAuth}}
+	_, err := failed.handle(nil)
+	assert.Equal(t, zk.ErrNoAuth, err)
+	assert.True(t, cli.LastSuccess().IsZero())
+
+	missing := &Watcher{client: cli, handler: &errorHandler{path: "/c", err: zk.ErrNoNode}}
+	_, err = missing.handle(nil)
+	assert.Equal(t, zk.ErrNoNode, err)
+	assert.False(t, cli.LastSuccess().IsZero())
+
+	lost := &Watcher{client: cli, handler: &errorHandler{path: "/a", err: zk.ErrSessionExpired}}
+	lost.Watch()
+
+	for i := 0; i < 100; i++ {
+		if _, dead := cli.WatcherCount(); dead > 0 && !lost.Alive() {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cli.register(failed)
+
+	statuses := cli.WatcherStatuses()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "/a", statuses[0].Path)
+	assert.False(t, statuses[0].Alive)
+	assert.Equal(t, zk.ErrSessionExpired, statuses[0].LastError)
+	assert.False(t, statuses[0].LastErrorTime.IsZero())
+	assert.Equal(t, "/b", statuses[1].Path)
+	assert.Equal(t, zk.ErrNoAuth, statuses[1].LastError)
+
+	alive, dead := cli.WatcherCount()
+	assert.Equal(t, 1, alive)
+	assert.Equal(t, 1, dead)
+
+	cli.unregister(failed)
+	assert.Len(t, cli.WatcherStatuses(), 1)
+}
@@ -41,3 +41,18 @@ func (c *JSONCodec) Decode(data []byte) (interface{}, error) {
 var (
 	jsonEncodeCodec = &JSONCodec{}
 )
+
+// setJSONCodecType set value type of the json codec, wrapped codec will be unwrapped
+func setJSONCodecType(codec Codec, typ reflect.Type) {
+	for {
+		switch c := codec.(type) {
+		case *JSONCodec:
+			c.typ = typ
+			return
+		case interface{ Unwrap() Codec }:
+			codec = c.Unwrap()
+		default:
+			return
+		}
+	}
+}
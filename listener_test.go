@@ -0,0 +1,106 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+type changeRecord struct {
+	child          string
+	oldObj, newObj interface{}
+	removed        bool
+}
+
+type childChangeRecorder struct {
+	records []*changeRecord
+}
+
+func (r *childChangeRecorder) Change(_, child string, _, _ *zk.Stat, oldObj, newObj interface{}) {
+	r.records = append(r.records, &changeRecord{child: child, oldObj: oldObj, newObj: newObj})
+}
+
+func (r *childChangeRecorder) Remove(_, child string, _ *zk.Stat, lastObj interface{}) {
+	r.records = append(r.records, &changeRecord{child: child, oldObj: lastObj, removed: true})
+}
+
+func TestChildChangeListener(t *testing.T) {
+	cli := &Client{}
+	r := &childChangeRecorder{}
+	users := make(map[string]*user)
+
+	h, err := cli.newMapHandler("/test/change_users", users, true, &JSONCodec{}, false, nil,
+		[]SyncOption{WithChildChangeListener(r)})
+	assert.Nil(t, err)
+
+	assert.Nil(t, h.Decode(&zk.Stat{Version: 0}, "u1", []byte(`{"name":"wongoo"}`)))
+	assert.Nil(t, h.Decode(&zk.Stat{Version: 1}, "u1", []byte(`{"name":"jack"}`)))
+	h.Delete("u1")
+
+	assert.Equal(t, 3, len(r.records))
+	assert.Nil(t, r.records[0].oldObj)
+	assert.Equal(t, "wongoo", r.records[0].newObj.(*user).Name)
+	assert.Equal(t, "wongoo", r.records[1].oldObj.(*user).Name)
+	assert.Equal(t, "jack", r.records[1].newObj.(*user).Name)
+	assert.True(t, r.records[2].removed)
+	assert.Equal(t, "jack", r.records[2].oldObj.(*user).Name)
+	assert.Equal(t, 0, len(users))
+}
+
+type valueChangeRecord struct {
+	oldStat, newStat *zk.Stat
+	oldObj, newObj   interface{}
+	removed          bool
+}
+
+type valueChangeRecorder struct {
+	records []*valueChangeRecord
+}
+
+func (r *valueChangeRecorder) Change(_ string, oldStat, newStat *zk.Stat, oldObj, newObj interface{}) {
+	r.records = append(r.records, &valueChangeRecord{oldStat: oldStat, newStat: newStat, oldObj: oldObj, newObj: newObj})
+}
+
+func (r *valueChangeRecorder) Remove(_ string, lastStat *zk.Stat, lastObj interface{}) {
+	r.records = append(r.records, &valueChangeRecord{oldStat: lastStat, oldObj: lastObj, removed: true})
+}
+
+func TestValueChangeListener(t *testing.T) {
+	cli := &Client{}
+	r := &valueChangeRecorder{}
+	u := &user{}
+
+	h, err := cli.newValueHandler("/test/change_user", u, &JSONCodec{}, false, nil,
+		[]SyncOption{WithValueChangeListener(r)})
+	assert.Nil(t, err)
+
+	stat0 := &zk.Stat{Version: 0}
+	stat1 := &zk.Stat{Version: 1}
+
+	assert.Nil(t, h.Decode(stat0, []byte(`{"name":"wongoo"}`)))
+	assert.Nil(t, h.Decode(stat1, []byte(`{"name":"jack"}`)))
+	h.remove()
+
+	assert.Equal(t, 3, len(r.records))
+
+	assert.Nil(t, r.records[0].oldStat)
+	assert.Nil(t, r.records[0].oldObj)
+	assert.Equal(t, stat0, r.records[0].newStat)
+	assert.Equal(t, "wongoo", r.records[0].newObj.(*user).Name)
+
+	assert.Equal(t, stat0, r.records[1].oldStat)
+	assert.Equal(t, stat1, r.records[1].newStat)
+	assert.Equal(t, "wongoo", r.records[1].oldObj.(*user).Name)
+	assert.Equal(t, "jack", r.records[1].newObj.(*user).Name)
+
+	assert.True(t, r.records[2].removed)
+	assert.Equal(t, stat1, r.records[2].oldStat)
+	assert.Equal(t, "jack", r.records[2].oldObj.(*user).Name)
+	assert.Equal(t, "jack", u.Name)
+}
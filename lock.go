@@ -0,0 +1,277 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	readLockPrefix  = "read-"
+	writeLockPrefix = "write-"
+	leasePrefix     = "lease-"
+
+	leaseRetryInterval = time.Second
+)
+
+// Lease handle of an acquired lock or semaphore lease, backed by an ephemeral sequential node,
+// which is released automatically when the session is lost.
+type Lease struct {
+	client *Client
+	path   string
+	once   sync.Once
+	done   chan struct{}
+	lost   chan struct{}
+}
+
+func newLease(cli *Client, path string) *Lease {
+	l := &Lease{
+		client: cli,
+		path:   path,
+		done:   make(chan struct{}),
+		lost:   make(chan struct{}),
+	}
+
+	go l.watch()
+
+	return l
+}
+
+// Path of the lease node
+func (l *Lease) Path() string {
+	return l.path
+}
+
+// Lost chan closed when the lease node is removed by others or by session expiration
+func (l *Lease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Release delete the lease node
+func (l *Lease) Release() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+
+	return l.client.Delete(l.path)
+}
+
+// watch the lease node, and close the lost chan when it's removed
+func (l *Lease) watch() {
+	defer close(l.lost)
+
+	for {
+		exists, _, ch, err := l.client.Conn().ExistsW(l.path)
+		if err == nil && !exists {
+			return
+		}
+
+		if err != nil {
+			l.client.log().Warn("zk failed to watch lease", "path", l.path, "error", err)
+
+			select {
+			case <-l.done:
+				return
+			case <-time.After(leaseRetryInterval):
+				continue
+			}
+		}
+
+		select {
+		case <-l.done:
+			return
+		case evt := <-ch:
+			if evt.Type == zk.EventNodeDeleted || evt.Type == zk.EventNotWatching {
+				l.client.log().Info("zk lease lost", "path", l.path)
+				return
+			}
+		}
+	}
+}
+
+// waitCondition report whether the node acquired, otherwise return the node to watch,
+// children changes will be watched if the returned node is empty.
+type waitCondition func(node string, nodes []string) (acquired bool, watch string)
+
+// acquire create an ephemeral sequential node under dir, and wait until the condition acquired
+func (cli *Client) acquire(ctx context.Context, dir, prefix string, condition waitCondition) (*Lease, error) {
+	prefixPath := PathJoin(dir, prefix)
+
+	if err := cli.checkWrite(prefixPath, nil); err != nil {
+		cli.audit(ctx, OpCreate, prefixPath, noVersion, 0, nil, err)
+		return nil, err
+	}
+
+	nodePath, err := cli.Conn().CreateProtectedEphemeralSequential(prefixPath, nil, zk.WorldACL(zk.PermAll))
+	if err == zk.ErrNoNode {
+		if err = cli.EnsurePathContext(ctx, dir); err != nil {
+			return nil, err
+		}
+
+		nodePath, err = cli.Conn().CreateProtectedEphemeralSequential(prefixPath, nil, zk.WorldACL(zk.PermAll))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cli.audit(ctx, OpCreate, nodePath, noVersion, 0, nil, nil)
+
+	node := nodePath[len(dir)+1:]
+
+	if err = cli.waitAcquired(ctx, dir, node, condition); err != nil {
+		if delErr := cli.DeleteContext(ctx, nodePath); delErr != nil {
+			cli.log().Warn("zk failed to delete node", "path", nodePath, "error", delErr)
+		}
+
+		return nil, err
+	}
+
+	return newLease(cli, nodePath), nil
+}
+
+func (cli *Client) waitAcquired(ctx context.Context, dir, node string, condition waitCondition) error {
+	for {
+		children, _, err := cli.Conn().Children(dir)
+		if err != nil {
+			return err
+		}
+
+		if !containsNode(children, node) {
+			return zk.ErrNoNode // removed by session expiration
+		}
+
+		sortSequenceNodes(children)
+
+		acquired, watch := condition(node, children)
+		if acquired {
+			return nil
+		}
+
+		var ch <-chan zk.Event
+
+		if watch == "" {
+			_, _, ch, err = cli.Conn().ChildrenW(dir)
+		} else {
+			var exists bool
+
+			exists, _, ch, err = cli.Conn().ExistsW(PathJoin(dir, watch))
+			if err == nil && !exists {
+				continue
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = cli.waitEvent(ctx, ch); err != nil {
+			return err
+		}
+	}
+}
+
+func containsNode(nodes []string, node string) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortSequenceNodes sort nodes by sequence number
+func sortSequenceNodes(nodes []string) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return SequenceLess(&ChildEvent{Child: nodes[i]}, &ChildEvent{Child: nodes[j]})
+	})
+}
+
+// RWLock distributed read-write lock, readers share the lock while writers own it exclusively
+type RWLock struct {
+	client *Client
+	path   string
+}
+
+// NewRWLock create read-write lock of the path, the path must be used only by this lock
+func (cli *Client) NewRWLock(path string) *RWLock {
+	return &RWLock{client: cli, path: path}
+}
+
+// RLock acquire shared read lock, wait until no writer ahead or the ctx is done
+func (l *RWLock) RLock(ctx context.Context) (*Lease, error) {
+	return l.client.acquire(ctx, l.path, readLockPrefix, func(node string, nodes []string) (bool, string) {
+		// watch the last writer ahead
+		watch := ""
+
+		for _, n := range nodes {
+			if n == node {
+				break
+			}
+
+			if strings.Contains(n, writeLockPrefix) {
+				watch = n
+			}
+		}
+
+		return watch == "", watch
+	})
+}
+
+// Lock acquire exclusive write lock, wait until no lock holder ahead or the ctx is done
+func (l *RWLock) Lock(ctx context.Context) (*Lease, error) {
+	return l.client.acquire(ctx, l.path, writeLockPrefix, func(node string, nodes []string) (bool, string) {
+		// watch the previous node
+		for i, n := range nodes {
+			if n == node {
+				if i == 0 {
+					return true, ""
+				}
+
+				return false, nodes[i-1]
+			}
+		}
+
+		return true, ""
+	})
+}
+
+// Semaphore distributed counting semaphore, at most maxLeases leases can be acquired at the same time
+type Semaphore struct {
+	client    *Client
+	path      string
+	maxLeases int
+}
+
+// NewSemaphore create semaphore of the path, the path must be used only by this semaphore
+func (cli *Client) NewSemaphore(path string, maxLeases int) (*Semaphore, error) {
+	if maxLeases <= 0 {
+		return nil, errors.New("max leases should be positive")
+	}
+
+	return &Semaphore{client: cli, path: path, maxLeases: maxLeases}, nil
+}
+
+// Acquire a lease, wait until available or the ctx is done
+func (s *Semaphore) Acquire(ctx context.Context) (*Lease, error) {
+	return s.client.acquire(ctx, s.path, leasePrefix, func(node string, nodes []string) (bool, string) {
+		for i, n := range nodes {
+			if n == node {
+				return i < s.maxLeases, ""
+			}
+		}
+
+		return true, ""
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortSequenceNodes(t *testing.T) {
+	nodes := []string{"_c_b-write-0000000003", "_c_a-read-0000000001", "_c_c-read-0000000002"}
+	sortSequenceNodes(nodes)
+	assert.Equal(t, []string{"_c_a-read-0000000001", "_c_c-read-0000000002", "_c_b-write-0000000003"}, nodes)
+}
+
+func TestRWLock(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	l := testClient.NewRWLock("/test/rwlock")
+	ctx := context.Background()
+
+	r1, err := l.RLock(ctx)
+	assert.Nil(t, err)
+
+	r2, err := l.RLock(ctx)
+	assert.Nil(t, err)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond*200)
+	defer cancel()
+
+	_, err = l.Lock(timeoutCtx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	assert.Nil(t, r1.Release())
+	assert.Nil(t, r2.Release())
+
+	w, err := l.Lock(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Release())
+}
+
+func TestSemaphore(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	s, err := testClient.NewSemaphore("/test/semaphore", 2)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+
+	l1, err := s.Acquire(ctx)
+	assert.Nil(t, err)
+
+	l2, err := s.Acquire(ctx)
+	assert.Nil(t, err)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond*200)
+	defer cancel()
+
+	_, err = s.Acquire(timeoutCtx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	assert.Nil(t, l1.Release())
+
+	l3, err := s.Acquire(ctx)
+	assert.Nil(t, err)
+
+	assert.Nil(t, l2.Release())
+	assert.Nil(t, l3.Release())
+}
@@ -0,0 +1,117 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/vogo/logger"
+)
+
+// Logger structured logger of the client, see WithLogger.
+// The keyvals are alternating keys and values, e.g. "path", "/a", "session", 1,
+// which is compatible with *slog.Logger.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// stdLogger default logger writing to github.com/vogo/logger
+type stdLogger struct{}
+
+func (stdLogger) Debug(msg string, keyvals ...interface{}) {
+	if logger.Level < logger.LevelDebug {
+		return
+	}
+
+	logger.Debug(formatLog(msg, keyvals))
+}
+
+func (stdLogger) Info(msg string, keyvals ...interface{}) {
+	logger.Info(formatLog(msg, keyvals))
+}
+
+func (stdLogger) Warn(msg string, keyvals ...interface{}) {
+	logger.Warn(formatLog(msg, keyvals))
+}
+
+func (stdLogger) Error(msg string, keyvals ...interface{}) {
+	logger.Error(formatLog(msg, keyvals))
+}
+
+// formatLog format the message followed by key=value pairs
+func formatLog(msg string, keyvals []interface{}) string {
+	if len(keyvals) == 0 {
+		return msg
+	}
+
+	var b strings.Builder
+
+	b.WriteString(msg)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 == len(keyvals) {
+			fmt.Fprintf(&b, " !BADKEY=%v", keyvals[i])
+			break
+		}
+
+		fmt.Fprintf(&b, " %v=%v", keyvals[i], keyvals[i+1])
+	}
+
+	return b.String()
+}
+
+// log return the logger of the client
+func (cli *Client) log() Logger {
+	if cli.logger == nil {
+		return stdLogger{}
+	}
+
+	return cli.logger
+}
+
+// SessionID of the current connection, 0 if not connected
+func (cli *Client) SessionID() int64 {
+	if cli.conn == nil {
+		return 0
+	}
+
+	return cli.conn.SessionID()
+}
+
+// zkLogger logger for zookeeper, only write log when debug level enabled
+type zkLogger struct {
+}
+
+// Printf only write log when debug level enabled
+func (l *zkLogger) Printf(format string, a ...interface{}) {
+	if logger.Level < logger.LevelDebug {
+		return
+	}
+
+	logger.WriteLog("ZOOK", fmt.Sprintf(format, a...))
+}
+
+// connLogger logger for zookeeper connection writing to the logger of the client
+type connLogger struct {
+	logger Logger
+	conn   atomic.Value
+}
+
+// Printf write debug log with the session id of the connection
+func (l *connLogger) Printf(format string, a ...interface{}) {
+	var session int64
+	if conn, ok := l.conn.Load().(*zk.Conn); ok {
+		session = conn.SessionID()
+	}
+
+	l.logger.Debug("zk "+fmt.Sprintf(format, a...), "session", session)
+}
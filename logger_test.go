@@ -0,0 +1,26 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalidTest = errors.New("invalid")
+
+func TestFormatLog(t *testing.T) {
+	assert.Equal(t, "zk read node", formatLog("zk read node", nil))
+	assert.Equal(t, "zk read node path=/a session=1", formatLog("zk read node", []interface{}{"path", "/a", "session", 1}))
+	assert.Equal(t, "zk read node path=/a !BADKEY=1", formatLog("zk read node", []interface{}{"path", "/a", 1}))
+
+	// default logger used if not set
+	cli := &Client{}
+	assert.Equal(t, stdLogger{}, cli.log())
+	assert.Equal(t, int64(0), cli.SessionID())
+}
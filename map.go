@@ -13,10 +13,10 @@ import (
 	"sync"
 
 	"github.com/samuel/go-zookeeper/zk"
-	"github.com/vogo/logger"
 )
 
 type mapHandler struct {
+	*SyncOptions
 	path        string
 	lock        sync.Mutex
 	value       reflect.Value
@@ -25,10 +25,11 @@ type mapHandler struct {
 	listenAsync bool
 	listener    ChildListener
 	children    map[string]struct{}
+	last        map[string]*ChildEvent
 }
 
 func (cli *Client) newMapHandler(path string, obj interface{}, syncChild bool, codec Codec,
-	watchOnly bool, listener ChildListener) (*mapHandler, error) {
+	watchOnly bool, listener ChildListener, options []SyncOption) (*mapHandler, error) {
 	if path == "" {
 		return nil, errors.New("path required")
 	}
@@ -56,16 +57,22 @@ func (cli *Client) newMapHandler(path string, obj interface{}, syncChild bool, c
 		return nil, errors.New("codec required")
 	}
 
-	if watchOnly && listener == nil {
+	syncOptions := newSyncOptions(options)
+	syncOptions.metrics = cli.metrics
+
+	if watchOnly && listener == nil && syncOptions.childChangeListener == nil {
 		return nil, errors.New("listener required when watch only")
 	}
 
 	// set json value type
-	if jsonCodec, ok := codec.(*JSONCodec); ok {
-		jsonCodec.typ = valueTyp.Elem()
+	setJSONCodecType(codec, valueTyp.Elem())
+
+	if syncOptions.coalesceWindow > 0 && listener != nil {
+		listener = newChildCoalescer(path, syncOptions.coalesceWindow, listener)
 	}
 
 	handler := &mapHandler{
+		SyncOptions: syncOptions,
 		path:        path,
 		syncChild:   syncChild,
 		codec:       codec,
@@ -73,6 +80,7 @@ func (cli *Client) newMapHandler(path string, obj interface{}, syncChild bool, c
 		listenAsync: cli.listenAsync,
 		listener:    listener,
 		children:    make(map[string]struct{}),
+		last:        make(map[string]*ChildEvent),
 	}
 
 	if !watchOnly {
@@ -104,20 +112,31 @@ func (h *mapHandler) Decode(stat *zk.Stat, key string, data []byte) error {
 		return err
 	}
 
+	if err = h.validate(PathJoin(h.path, key), v); err != nil {
+		return err
+	}
+
 	if h.value != nilValue {
 		h.value.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(v))
 	}
 
 	if h.listener != nil {
-		f := func() {
+		h.listen(h.listenAsync, h.path, func() {
 			h.listener.Update(h.path, key, stat, v)
-		}
+		})
+	}
 
-		if h.listenAsync {
-			go f()
-		} else {
-			f()
+	if h.childChangeListener != nil {
+		last, ok := h.last[key]
+		if !ok {
+			last = &ChildEvent{Child: key}
 		}
+
+		h.last[key] = &ChildEvent{Child: key, Stat: stat, Value: v}
+
+		h.listen(h.listenAsync, h.path, func() {
+			h.childChangeListener.Change(h.path, key, last.Stat, stat, last.Value, v)
+		})
 	}
 
 	return nil
@@ -132,15 +151,17 @@ func (h *mapHandler) Delete(key string) {
 	}
 
 	if h.listener != nil {
-		f := func() {
+		h.listen(h.listenAsync, h.path, func() {
 			h.listener.Delete(h.path, key)
-		}
+		})
+	}
 
-		if h.listenAsync {
-			go f()
-		} else {
-			f()
-		}
+	if last, ok := h.last[key]; ok {
+		delete(h.last, key)
+
+		h.listen(h.listenAsync, h.path, func() {
+			h.childChangeListener.Remove(h.path, key, last.Stat, last.Value)
+		})
 	}
 }
 
@@ -150,7 +171,7 @@ func (h *mapHandler) Path() string {
 
 func (h *mapHandler) Handle(w *Watcher, evt *zk.Event) (<-chan zk.Event, error) {
 	if evt != nil && evt.Type == zk.EventNodeDeleted {
-		logger.Infof("zk watcher [%s] node deleted", h.path)
+		w.client.log().Info("zk watcher node deleted", "path", h.path)
 
 		for child := range h.children {
 			h.Delete(child)
@@ -184,7 +205,7 @@ func (h *mapHandler) Handle(w *Watcher, evt *zk.Event) (<-chan zk.Event, error)
 
 	for child := range oldChildren {
 		if _, ok := newChildren[child]; !ok {
-			logger.Infof("zk delete sub node [%s] of [%s]", child, h.path)
+			w.client.log().Info("zk delete sub node", "path", h.path, "child", child)
 			h.Delete(child)
 		}
 	}
@@ -216,7 +237,7 @@ func (h *mapHandler) syncWatchChild(w *Watcher, child string) {
 
 	if !h.syncChild {
 		if _, err := h.handleChild(w.client, childPath); err != nil {
-			logger.Errorf("zk load map child error: %v", err)
+			w.client.log().Error("zk load map child error", "path", childPath, "error", err)
 		}
 
 		return
@@ -235,7 +256,7 @@ func (h *mapHandler) handleChild(client *Client, childPath string) (<-chan zk.Ev
 		stat *zk.Stat
 	)
 
-	logger.Debugf("zk read node [%s]", childPath)
+	client.log().Debug("zk read node", "path", childPath)
 
 	if h.syncChild {
 		data, stat, ch, err = client.Conn().GetW(childPath)
@@ -249,13 +270,17 @@ func (h *mapHandler) handleChild(client *Client, childPath string) (<-chan zk.Ev
 		}
 	}
 
-	if err = h.Decode(stat, filepath.Base(childPath), data); err != nil {
-		if err != io.EOF {
-			logger.Warnf("zk failed to parse %s: %v", childPath, err)
-		}
+	if data, err = client.resolveRawValue(childPath, data); err != nil {
+		client.log().Warn("zk failed to load chunks", "path", childPath, "error", err)
+		return ch, nil
+	}
 
+	if err = h.Decode(stat, filepath.Base(childPath), data); err != nil {
+		h.reportError(client, childPath, err)
 		return ch, nil
 	}
 
+	client.writeBackMigration(childPath, stat, data, h.codec)
+
 	return ch, nil
 }
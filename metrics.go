@@ -0,0 +1,88 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// operation types reported to Metrics
+const (
+	OpGet        = "get"
+	OpSet        = "set"
+	OpCreate     = "create"
+	OpDelete     = "delete"
+	OpChildren   = "children"
+	OpExists     = "exists"
+	OpEnsurePath = "ensure_path"
+)
+
+// Metrics hook of client metrics, see WithMetrics.
+// The methods are called synchronously, and should return quickly.
+type Metrics interface {
+	// Reconnected the session is re-established after lost
+	Reconnected()
+
+	// ConnectionState the connection state changed
+	ConnectionState(state zk.State)
+
+	// Operation an operation of the op type finished
+	Operation(op string, duration time.Duration, err error)
+
+	// Watchers count of alive watchers, and dead watchers queued to watch again
+	Watchers(alive, dead int)
+
+	// DecodeFailure failed to decode or validate the value of the path
+	DecodeFailure(path string)
+
+	// ListenerLatency time used by the listener of the path
+	ListenerLatency(path string, duration time.Duration)
+}
+
+// onEvent report session state changes
+func (cli *Client) onEvent(evt zk.Event) {
+	if evt.Type != zk.EventSession || cli.metrics == nil {
+		return
+	}
+
+	cli.metrics.ConnectionState(evt.State)
+
+	if evt.State == zk.StateHasSession && atomic.AddInt32(&cli.sessions, 1) > 1 {
+		cli.metrics.Reconnected()
+	}
+}
+
+// WatcherCount count of alive watchers, and dead watchers queued to watch again
+func (cli *Client) WatcherCount() (alive, dead int) {
+	cli.Lock()
+	defer cli.Unlock()
+
+	return len(cli.watchers), len(cli.deadWatchers)
+}
+
+// reportWatchers report the watcher count, must not be called with lock held
+func (cli *Client) reportWatchers() {
+	if cli.metrics != nil {
+		cli.metrics.Watchers(cli.WatcherCount())
+	}
+}
+
+// listen call the listener function of the path, and report the latency
+func (o *SyncOptions) listen(async bool, path string, f func()) {
+	if o.metrics == nil {
+		callListener(async, f)
+		return
+	}
+
+	callListener(async, func() {
+		start := time.Now()
+		f()
+		o.metrics.ListenerLatency(path, time.Since(start))
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+type metricsRecorder struct {
+	reconnects     int
+	states         []zk.State
+	ops            []string
+	alive, dead    int
+	decodeFailures []string
+	listeners      []string
+}
+
+func (r *metricsRecorder) Reconnected() { r.reconnects++ }
+
+func (r *metricsRecorder) ConnectionState(state zk.State) { r.states = append(r.states, state) }
+
+func (r *metricsRecorder) Operation(op string, _ time.Duration, err error) {
+	if err != nil {
+		op += ":" + err.Error()
+	}
+
+	r.ops = append(r.ops, op)
+}
+
+func (r *metricsRecorder) Watchers(alive, dead int) { r.alive, r.dead = alive, dead }
+
+func (r *metricsRecorder) DecodeFailure(path string) {
+	r.decodeFailures = append(r.decodeFailures, path)
+}
+
+func (r *metricsRecorder) ListenerLatency(path string, _ time.Duration) {
+	r.listeners = append(r.listeners, path)
+}
+
+func TestMetrics(t *testing.T) {
+	r := &metricsRecorder{}
+	cli := &Client{}
+	cli.metrics = r
+
+	_, o := cli.startOperation(context.Background(), OpGet, "/a")
+	o.end(nil)
+	_, o = cli.startOperation(context.Background(), OpSet, "/a")
+	o.end(errors.New("failed"))
+	assert.Equal(t, []string{OpGet, OpSet + ":failed"}, r.ops)
+
+	cli.onEvent(zk.Event{Type: zk.EventSession, State: zk.StateHasSession})
+	cli.onEvent(zk.Event{Type: zk.EventNodeCreated})
+	cli.onEvent(zk.Event{Type: zk.EventSession, State: zk.StateDisconnected})
+	cli.onEvent(zk.Event{Type: zk.EventSession, State: zk.StateHasSession})
+	assert.Equal(t, []zk.State{zk.StateHasSession, zk.StateDisconnected, zk.StateHasSession}, r.states)
+	assert.Equal(t, 1, r.reconnects)
+
+	cli.AppendDeadWatcher(&Watcher{handler: &mirrorNodeHandler{path: "/a"}})
+	assert.Equal(t, 1, r.dead)
+
+	options := newSyncOptions(nil)
+	options.metrics = cli.metrics
+	options.reportError(cli, "/a", errors.New("invalid"))
+	options.listen(false, "/a", func() {})
+	assert.Equal(t, []string{"/a"}, r.decodeFailures)
+	assert.Equal(t, []string{"/a"}, r.listeners)
+
+	// metrics is optional
+	cli = &Client{}
+	_, o = cli.startOperation(context.Background(), OpGet, "/a")
+	o.version(1)
+	o.end(nil)
+	newSyncOptions(nil).listen(false, "/a", func() {})
+}
@@ -0,0 +1,404 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// MirrorConflictPolicy decide whether to overwrite the target node which was changed in the target cluster,
+// the source data is nil for deleting.
+type MirrorConflictPolicy func(targetPath string, source, target []byte) bool
+
+var (
+	// MirrorSourceWins always overwrite the target
+	MirrorSourceWins MirrorConflictPolicy = func(string, []byte, []byte) bool { return true }
+
+	// MirrorTargetWins keep the target changed in the target cluster
+	MirrorTargetWins MirrorConflictPolicy = func(string, []byte, []byte) bool { return false }
+)
+
+// MirrorOption option of mirror
+type MirrorOption func(*MirrorOptions)
+
+// MirrorOptions options of mirror
+type MirrorOptions struct {
+	rewrite        func(sourcePath string) string
+	conflictPolicy MirrorConflictPolicy
+}
+
+// WithMirrorRewrite rewrite source path to target path, the node is not mirrored if empty path returned
+func WithMirrorRewrite(rewrite func(sourcePath string) string) MirrorOption {
+	return func(o *MirrorOptions) {
+		o.rewrite = rewrite
+	}
+}
+
+// WithMirrorConflictPolicy set the conflict policy, default MirrorSourceWins
+func WithMirrorConflictPolicy(policy MirrorConflictPolicy) MirrorOption {
+	return func(o *MirrorOptions) {
+		o.conflictPolicy = policy
+	}
+}
+
+// MirrorStats statistics of mirror, lag is the duration from the source modified time to replicated
+type MirrorStats struct {
+	Replicated     int64
+	Deleted        int64
+	Conflicts      int64
+	Errors         int64
+	LastLag        time.Duration
+	MaxLag         time.Duration
+	LastReplicated time.Time
+}
+
+// Mirror replicate creates, updates and deletes of the source subtree to the target cluster.
+// Ephemeral nodes are not mirrored. A target node is in conflict if it was changed
+// in the target cluster since mirrored, or differs from the source when first mirrored.
+type Mirror struct {
+	sync.Mutex
+	MirrorOptions
+	source     *Client
+	target     *Client
+	sourcePath string
+	targetPath string
+	versions   map[string]int32
+	watching   map[string]struct{}
+	stats      MirrorStats
+	watchers   []*Watcher
+}
+
+// NewMirror start mirroring the source path of the client to the target path of the target client
+func (cli *Client) NewMirror(target *Client, sourcePath, targetPath string, options ...MirrorOption) (*Mirror, error) {
+	if target == nil || sourcePath == "" || targetPath == "" {
+		return nil, errors.New("target client, source path and target path required")
+	}
+
+	m := &Mirror{
+		source:     cli,
+		target:     target,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+		versions:   make(map[string]int32),
+		watching:   make(map[string]struct{}),
+	}
+
+	for _, option := range options {
+		option(&m.MirrorOptions)
+	}
+
+	if m.rewrite == nil {
+		m.rewrite = m.rewritePrefix
+	}
+
+	if m.conflictPolicy == nil {
+		m.conflictPolicy = MirrorSourceWins
+	}
+
+	for _, handler := range []EventHandler{
+		&mirrorNodeHandler{mirror: m, path: sourcePath},
+		&mirrorChildrenHandler{mirror: m, path: sourcePath},
+	} {
+		watcher, err := cli.createWatcher(handler)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+
+		m.watchers = append(m.watchers, watcher)
+	}
+
+	return m, nil
+}
+
+// Stats of the mirror
+func (m *Mirror) Stats() MirrorStats {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.stats
+}
+
+// Close stop mirroring
+func (m *Mirror) Close() {
+	for _, watcher := range m.watchers {
+		watcher.Close()
+	}
+}
+
+// rewritePrefix replace the source path prefix with the target path
+func (m *Mirror) rewritePrefix(sourcePath string) string {
+	rest := strings.TrimPrefix(sourcePath, m.sourcePath)
+	if rest == sourcePath || rest != "" && !strings.HasPrefix(rest, PathSplit) {
+		return ""
+	}
+
+	return m.targetPath + rest
+}
+
+// watchNode watch value and children of the source node by child watchers of the parent, if not watched yet
+func (m *Mirror) watchNode(parent *Watcher, path string) {
+	m.Lock()
+	_, watched := m.watching[path]
+	m.watching[path] = nilStruct
+	m.Unlock()
+
+	if watched {
+		return
+	}
+
+	parent.newChildWatcher(&mirrorNodeHandler{mirror: m, path: path}).Watch()
+	parent.newChildWatcher(&mirrorChildrenHandler{mirror: m, path: path}).Watch()
+}
+
+// unwatchNode exit watching the deleted source node, which will be watched again by the parent when created.
+// The node is watched again immediately if it was created before the parent could see the deletion.
+func (m *Mirror) unwatchNode(w *Watcher, path string) (<-chan zk.Event, error) {
+	m.Lock()
+	delete(m.watching, path)
+	m.Unlock()
+
+	exists, _, err := w.client.Conn().Exists(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		m.watchNode(w, path)
+	}
+
+	return nil, nil
+}
+
+// waitRoot wait for the source root to be created, and exit watching other deleted nodes
+func (m *Mirror) waitRoot(w *Watcher, path string) (<-chan zk.Event, error) {
+	if path != m.sourcePath {
+		return nil, nil
+	}
+
+	exists, _, ch, err := w.client.Conn().ExistsW(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		// created after checked, handle it immediately
+		created := make(chan zk.Event, 1)
+		created <- zk.Event{Type: zk.EventNodeCreated, State: zk.StateHasSession, Path: path}
+
+		return created, nil
+	}
+
+	return ch, nil
+}
+
+func (m *Mirror) countError(msg, targetPath string, err error) {
+	m.source.log().Warn(msg, "path", targetPath, "error", err)
+
+	m.Lock()
+	m.stats.Errors++
+	m.Unlock()
+}
+
+// conflicted check whether the target was changed in the target cluster, and not to be overwritten
+func (m *Mirror) conflicted(targetPath string, stat *zk.Stat, source, target []byte) bool {
+	m.Lock()
+	version, mirrored := m.versions[targetPath]
+	m.Unlock()
+
+	if mirrored && version == stat.Version {
+		return false
+	}
+
+	if m.conflictPolicy(targetPath, source, target) {
+		return false
+	}
+
+	m.source.log().Info("zk mirror keep conflicted target", "path", targetPath)
+
+	m.Lock()
+	m.stats.Conflicts++
+	m.Unlock()
+
+	return true
+}
+
+// replicate the source value to the target
+func (m *Mirror) replicate(sourcePath string, data []byte, stat *zk.Stat, changed bool) {
+	targetPath := m.rewrite(sourcePath)
+	if targetPath == "" {
+		return
+	}
+
+	current, targetStat, err := m.target.GetRawValue(targetPath)
+
+	switch {
+	case err == zk.ErrNoNode:
+		err = m.target.SetRawValue(targetPath, data)
+	case err != nil:
+	case bytes.Equal(current, data):
+		m.record(targetPath, targetStat.Version)
+		return
+	case m.conflicted(targetPath, targetStat, data, current):
+		return
+	default:
+		err = m.target.SetRawValueVersion(targetPath, data, targetStat.Version)
+	}
+
+	if err != nil {
+		m.countError("zk mirror failed to replicate", targetPath, err)
+		return
+	}
+
+	exists, targetStat, err := m.target.Conn().Exists(targetPath)
+	if err != nil || !exists {
+		m.countError("zk mirror failed to stat", targetPath, err)
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.versions[targetPath] = targetStat.Version
+	m.stats.Replicated++
+	m.stats.LastReplicated = time.Now()
+
+	if changed {
+		lag := time.Since(time.Unix(0, stat.Mtime*int64(time.Millisecond)))
+		m.stats.LastLag = lag
+
+		if lag > m.stats.MaxLag {
+			m.stats.MaxLag = lag
+		}
+	}
+}
+
+func (m *Mirror) record(targetPath string, version int32) {
+	m.Lock()
+	m.versions[targetPath] = version
+	m.Unlock()
+}
+
+// remove the target of the deleted source node
+func (m *Mirror) remove(sourcePath string) {
+	targetPath := m.rewrite(sourcePath)
+	if targetPath == "" {
+		return
+	}
+
+	current, targetStat, err := m.target.GetRawValue(targetPath)
+	if err == zk.ErrNoNode {
+		return
+	}
+
+	if err != nil {
+		m.countError("zk mirror failed to get", targetPath, err)
+		return
+	}
+
+	if m.conflicted(targetPath, targetStat, nil, current) {
+		return
+	}
+
+	// the source parent may be handled before its deleted children, delete the target subtree together
+	if err = m.target.DeleteRecursive(targetPath); err != nil {
+		m.countError("zk mirror failed to delete", targetPath, err)
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for p := range m.versions {
+		if matchPrefix(p, targetPath) {
+			delete(m.versions, p)
+		}
+	}
+
+	m.stats.Deleted++
+}
+
+// mirrorNodeHandler replicate the value of the source node
+type mirrorNodeHandler struct {
+	mirror    *Mirror
+	path      string
+	ephemeral bool
+}
+
+func (h *mirrorNodeHandler) Path() string {
+	return h.path
+}
+
+func (h *mirrorNodeHandler) Handle(w *Watcher, evt *zk.Event) (<-chan zk.Event, error) {
+	data, stat, ch, err := w.client.Conn().GetW(h.path)
+	if err == zk.ErrNoNode {
+		if !h.ephemeral {
+			h.mirror.remove(h.path)
+		}
+
+		h.ephemeral = false
+
+		if h.path != h.mirror.sourcePath {
+			return h.mirror.unwatchNode(w, h.path)
+		}
+
+		return h.mirror.waitRoot(w, h.path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// keep watching ephemeral nodes until deleted, but not mirrored
+	h.ephemeral = stat.EphemeralOwner != 0
+	if h.ephemeral {
+		return ch, nil
+	}
+
+	if data, err = w.client.resolveRawValue(h.path, data); err != nil {
+		w.client.log().Warn("zk mirror failed to load chunks", "path", h.path, "error", err)
+		return ch, nil
+	}
+
+	h.mirror.replicate(h.path, data, stat, evt != nil)
+
+	return ch, nil
+}
+
+// mirrorChildrenHandler watch new children of the source node
+type mirrorChildrenHandler struct {
+	mirror *Mirror
+	path   string
+}
+
+func (h *mirrorChildrenHandler) Path() string {
+	return h.path
+}
+
+func (h *mirrorChildrenHandler) Handle(w *Watcher, _ *zk.Event) (<-chan zk.Event, error) {
+	children, _, ch, err := w.client.Conn().ChildrenW(h.path)
+	if err == zk.ErrNoNode {
+		return h.mirror.waitRoot(w, h.path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		if !isChunkNode(child) {
+			h.mirror.watchNode(w, childNodePath(h.path, child))
+		}
+	}
+
+	return ch, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirror(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	_ = testClient.DeleteRecursive("/test/mirror")
+	defer func() { _ = testClient.DeleteRecursive("/test/mirror") }()
+
+	assert.Nil(t, testClient.SetString("/test/mirror/source/a", "1"))
+	assert.Nil(t, testClient.SetTempString("/test/mirror/source/temp", "x"))
+
+	target := NewClient([]string{"127.0.0.1:2181"})
+	defer target.Close()
+
+	waitEventWatch()
+
+	m, err := testClient.NewMirror(target, "/test/mirror/source", "/test/mirror/target",
+		WithMirrorConflictPolicy(MirrorTargetWins))
+	assert.Nil(t, err)
+
+	defer m.Close()
+
+	waitEventWatch()
+
+	s, _ := target.GetString("/test/mirror/target/a")
+	assert.Equal(t, "1", s)
+
+	exists, _ := target.Exists("/test/mirror/target/temp")
+	assert.False(t, exists)
+
+	// create and update
+	assert.Nil(t, testClient.SetString("/test/mirror/source/b/c", "2"))
+	assert.Nil(t, testClient.SetString("/test/mirror/source/a", "3"))
+	waitEventWatch()
+
+	s, _ = target.GetString("/test/mirror/target/b/c")
+	assert.Equal(t, "2", s)
+	s, _ = target.GetString("/test/mirror/target/a")
+	assert.Equal(t, "3", s)
+
+	// changed in target is kept by MirrorTargetWins
+	assert.Nil(t, target.SetString("/test/mirror/target/a", "local"))
+	assert.Nil(t, testClient.SetString("/test/mirror/source/a", "4"))
+	waitEventWatch()
+
+	s, _ = target.GetString("/test/mirror/target/a")
+	assert.Equal(t, "local", s)
+	assert.Equal(t, int64(1), m.Stats().Conflicts)
+
+	// delete
+	assert.Nil(t, testClient.Delete("/test/mirror/source/b/c"))
+	waitEventWatch()
+
+	exists, _ = target.Exists("/test/mirror/target/b/c")
+	assert.False(t, exists)
+	assert.True(t, m.Stats().Replicated > 0)
+
+	// delete and create again quickly
+	assert.Nil(t, testClient.SetString("/test/mirror/source/b/d", "5"))
+	waitEventWatch()
+	assert.Nil(t, testClient.Delete("/test/mirror/source/b/d"))
+	assert.Nil(t, testClient.SetString("/test/mirror/source/b/d", "6"))
+	waitEventWatch()
+	assert.Nil(t, testClient.SetString("/test/mirror/source/b/d", "7"))
+	waitEventWatch()
+
+	s, _ = target.GetString("/test/mirror/target/b/d")
+	assert.Equal(t, "7", s)
+
+	// delete the parent together with its children
+	assert.Nil(t, testClient.SetString("/test/mirror/source/b/e/f", "8"))
+	waitEventWatch()
+	assert.Nil(t, testClient.DeleteRecursive("/test/mirror/source/b"))
+	waitEventWatch()
+
+	exists, _ = target.Exists("/test/mirror/target/b")
+	assert.False(t, exists)
+}
+
+func TestMirrorRewrite(t *testing.T) {
+	m := &Mirror{sourcePath: "/a", targetPath: "/b/c"}
+
+	assert.Equal(t, "/b/c", m.rewritePrefix("/a"))
+	assert.Equal(t, "/b/c/x/y", m.rewritePrefix("/a/x/y"))
+	assert.Equal(t, "", m.rewritePrefix("/other"))
+	assert.Equal(t, "", m.rewritePrefix("/ab"))
+}
@@ -11,6 +11,20 @@ type ClientOptions struct {
 	listenAsync  bool
 	timeout      time.Duration
 	alarmTrigger AlarmTrigger
+	chunkSize    int
+	auths        []clientAuth
+	metrics      Metrics
+	tracer       Tracer
+	logger       Logger
+	auditSink    AuditSink
+	auditActor   string
+	writePolicy  *WritePolicy
+}
+
+// clientAuth auth added to each new connection
+type clientAuth struct {
+	scheme string
+	auth   []byte
 }
 
 func WithListenAsync(async bool) ClientOption {
@@ -30,3 +44,121 @@ func WithAlarmTrigger(trigger AlarmTrigger) ClientOption {
 		o.alarmTrigger = trigger
 	}
 }
+
+// WithChunkSize enable chunked storage mode, value larger than size will be split into child chunk nodes
+func WithChunkSize(size int) ClientOption {
+	return func(o *ClientOptions) {
+		o.chunkSize = size
+	}
+}
+
+// WithAuth add auth of the scheme (e.g. digest with `user:password`) to the connection, it's added again after reconnecting
+func WithAuth(scheme string, auth []byte) ClientOption {
+	return func(o *ClientOptions) {
+		o.auths = append(o.auths, clientAuth{scheme: scheme, auth: auth})
+	}
+}
+
+// WithMetrics report metrics of connection, operations, watchers and listeners to the hook
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(o *ClientOptions) {
+		o.metrics = metrics
+	}
+}
+
+// WithTracer trace client operations and watch handle cycles by the tracer
+func WithTracer(tracer Tracer) ClientOption {
+	return func(o *ClientOptions) {
+		o.tracer = tracer
+	}
+}
+
+// WithLogger write logs of the client, watchers and the zookeeper connection to the logger,
+// default to github.com/vogo/logger.
+func WithLogger(logger Logger) ClientOption {
+	return func(o *ClientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithAuditSink record writes performed through the client to the sink
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(o *ClientOptions) {
+		o.auditSink = sink
+	}
+}
+
+// WithAuditActor default actor of audit records, if the ctx carries no actor
+func WithAuditActor(actor string) ClientOption {
+	return func(o *ClientOptions) {
+		o.auditActor = actor
+	}
+}
+
+// WithWritePolicy reject writes violating the policy before reaching zookeeper
+func WithWritePolicy(policy *WritePolicy) ClientOption {
+	return func(o *ClientOptions) {
+		o.writePolicy = policy
+	}
+}
+
+// SyncOption option for synchronizing value
+type SyncOption func(*SyncOptions)
+
+// SyncOptions options for synchronizing value
+type SyncOptions struct {
+	validator      Validator
+	errorHandler   ErrorHandler
+	coalesceWindow time.Duration
+
+	valueChangeListener ValueChangeListener
+	childChangeListener ChildChangeListener
+
+	metrics Metrics
+}
+
+func newSyncOptions(options []SyncOption) *SyncOptions {
+	o := &SyncOptions{}
+
+	for _, option := range options {
+		option(o)
+	}
+
+	return o
+}
+
+// WithValidator validate decoded value before synchronizing it
+func WithValidator(validator Validator) SyncOption {
+	return func(o *SyncOptions) {
+		o.validator = validator
+	}
+}
+
+// WithErrorHandler handle errors of decoding and validating value
+func WithErrorHandler(handler ErrorHandler) SyncOption {
+	return func(o *SyncOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// WithCoalesce coalesce child changes within the window, and deliver them to the listener once,
+// see BatchListener.
+func WithCoalesce(window time.Duration) SyncOption {
+	return func(o *SyncOptions) {
+		o.coalesceWindow = window
+	}
+}
+
+// WithValueChangeListener listen value change with the previous value
+func WithValueChangeListener(listener ValueChangeListener) SyncOption {
+	return func(o *SyncOptions) {
+		o.valueChangeListener = listener
+	}
+}
+
+// WithChildChangeListener listen child value change with the previous value
+func WithChildChangeListener(listener ChildChangeListener) SyncOption {
+	return func(o *SyncOptions) {
+		o.childChangeListener = listener
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+var (
+	// ErrReadOnly write to a read-only path
+	ErrReadOnly = errors.New("read-only path")
+
+	// ErrPathNotAllowed write to a path out of the allowed prefixes
+	ErrPathNotAllowed = errors.New("path not allowed")
+
+	// ErrPayloadTooLarge payload larger than the max payload size
+	ErrPayloadTooLarge = errors.New("payload too large")
+)
+
+// WritePolicy policy of writes through the client, rejected writes never reach zookeeper, see WithWritePolicy.
+// A prefix matches the path itself and its descendants, e.g. "/a" matches "/a" and "/a/b" but not "/ab".
+type WritePolicy struct {
+	// ReadOnly reject all writes
+	ReadOnly bool
+
+	// ReadOnlyPrefixes reject writes to paths matching any prefix
+	ReadOnlyPrefixes []string
+
+	// AllowedPrefixes only allow writes to paths matching any prefix if not empty,
+	// ancestors of the prefixes can still be created.
+	AllowedPrefixes []string
+
+	// MaxPayloadSize reject payloads larger than the size if positive
+	MaxPayloadSize int
+
+	// RequiredCodecs payloads written to paths matching the prefix must be decoded by the codec
+	RequiredCodecs map[string]Codec
+}
+
+// PolicyError error of a write rejected by the write policy
+type PolicyError struct {
+	Path string
+	Err  error
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("write to %s rejected: %v", e.Path, e.Err)
+}
+
+// Unwrap the cause
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// matchPrefix whether the path is the prefix or a descendant of it
+func matchPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, PathSplit)
+
+	return path == prefix || strings.HasPrefix(path, prefix+PathSplit)
+}
+
+// matchAnyPrefix whether the path matches any of the prefixes
+func matchAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if matchPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPath check whether the path is writable
+func (p *WritePolicy) checkPath(path string) error {
+	if p.ReadOnly || matchAnyPrefix(path, p.ReadOnlyPrefixes) {
+		return &PolicyError{Path: path, Err: ErrReadOnly}
+	}
+
+	if len(p.AllowedPrefixes) > 0 && !matchAnyPrefix(path, p.AllowedPrefixes) {
+		return &PolicyError{Path: path, Err: ErrPathNotAllowed}
+	}
+
+	return nil
+}
+
+// checkPayload check whether the payload is acceptable for the path
+func (p *WritePolicy) checkPayload(path string, data []byte) error {
+	if p.MaxPayloadSize > 0 && len(data) > p.MaxPayloadSize {
+		return &PolicyError{Path: path, Err: ErrPayloadTooLarge}
+	}
+
+	for prefix, codec := range p.RequiredCodecs {
+		if !matchPrefix(path, prefix) {
+			continue
+		}
+
+		if _, err := codec.Decode(data); err != nil {
+			return &PolicyError{Path: path, Err: fmt.Errorf("invalid payload: %w", err)}
+		}
+	}
+
+	return nil
+}
+
+// checkWrite check the write of the payload to the path
+func (cli *Client) checkWrite(path string, data []byte) error {
+	if cli.writePolicy == nil {
+		return nil
+	}
+
+	if err := cli.writePolicy.checkPath(path); err != nil {
+		return err
+	}
+
+	return cli.writePolicy.checkPayload(path, data)
+}
+
+// checkDelete check the delete of the path, and of its descendants if recursive
+func (cli *Client) checkDelete(path string, recursive bool) error {
+	p := cli.writePolicy
+	if p == nil {
+		return nil
+	}
+
+	if err := p.checkPath(path); err != nil {
+		return err
+	}
+
+	if recursive {
+		for _, prefix := range p.ReadOnlyPrefixes {
+			if matchPrefix(prefix, path) {
+				return &PolicyError{Path: path, Err: ErrReadOnly}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkCreate check the creation of the empty path node,
+// ancestors of the allowed prefixes can be created to reach them.
+func (cli *Client) checkCreate(path string) error {
+	p := cli.writePolicy
+	if p == nil {
+		return nil
+	}
+
+	err := p.checkPath(path)
+	if !errors.Is(err, ErrPathNotAllowed) {
+		return err
+	}
+
+	for _, prefix := range p.AllowedPrefixes {
+		if matchPrefix(prefix, path) {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// checkOps check the paths and the payloads of the operations in a transaction
+func (cli *Client) checkOps(ops []interface{}) error {
+	if cli.writePolicy == nil {
+		return nil
+	}
+
+	for _, op := range ops {
+		var err error
+
+		switch req := op.(type) {
+		case *zk.CreateRequest:
+			err = cli.checkWrite(req.Path, req.Data)
+		case *zk.SetDataRequest:
+			err = cli.checkWrite(req.Path, req.Data)
+		case *zk.DeleteRequest:
+			err = cli.writePolicy.checkPath(req.Path)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
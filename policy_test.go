@@ -0,0 +1,90 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPrefix(t *testing.T) {
+	assert.True(t, matchPrefix("/a", "/a"))
+	assert.True(t, matchPrefix("/a/b", "/a"))
+	assert.True(t, matchPrefix("/a/b", "/a/"))
+	assert.True(t, matchPrefix("/a", "/"))
+	assert.False(t, matchPrefix("/ab", "/a"))
+	assert.False(t, matchPrefix("/a", "/a/b"))
+}
+
+func TestWritePolicy(t *testing.T) {
+	type config struct {
+		Name string `json:"name"`
+	}
+
+	r := &auditRecorder{}
+	cli := &Client{}
+	cli.auditSink = r
+	cli.writePolicy = &WritePolicy{
+		ReadOnlyPrefixes: []string{"/app/shared"},
+		AllowedPrefixes:  []string{"/app"},
+		MaxPayloadSize:   16,
+		RequiredCodecs:   map[string]Codec{"/app/config": &JSONCodec{typ: reflect.TypeOf(config{})}},
+	}
+
+	isPolicyError := func(err, target error) {
+		var policyErr *PolicyError
+		assert.True(t, errors.As(err, &policyErr))
+		assert.True(t, errors.Is(err, target), err)
+	}
+
+	isPolicyError(cli.SetRawValue("/app/shared/a", []byte("1")), ErrReadOnly)
+	isPolicyError(cli.SetRawValueVersion("/app/shared", []byte("1"), 1), ErrReadOnly)
+	isPolicyError(cli.SetTempRawValue("/other", []byte("1")), ErrPathNotAllowed)
+	isPolicyError(cli.SetRawValue("/app/a", make([]byte, 17)), ErrPayloadTooLarge)
+	isPolicyError(cli.Delete("/app/shared/a"), ErrReadOnly)
+	isPolicyError(cli.DeleteRecursive("/app"), ErrReadOnly)
+	isPolicyError(cli.multi(&zk.CreateRequest{Path: "/other/tx"}), ErrPathNotAllowed)
+	isPolicyError(cli.multi(&zk.SetDataRequest{Path: "/app/a", Data: make([]byte, 17)}), ErrPayloadTooLarge)
+
+	err := cli.SetRawValue("/app/config/a", []byte("not json"))
+	var policyErr *PolicyError
+	assert.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, "/app/config/a", policyErr.Path)
+
+	assert.Nil(t, cli.checkWrite("/app/config/a", []byte(`{"name":"a"}`)))
+	assert.Nil(t, cli.checkDelete("/app/a", true))
+	assert.Nil(t, cli.checkCreate("/"))
+	isPolicyError(cli.checkCreate("/other"), ErrPathNotAllowed)
+	isPolicyError(cli.checkCreate("/app/shared"), ErrReadOnly)
+
+	// rejected writes are audited, including each operation of transactions
+	assert.Len(t, r.records, 9)
+	assert.Equal(t, OpSet, r.records[0].Op)
+	assert.Equal(t, "/app/shared/a", r.records[0].Path)
+	assert.NotEmpty(t, r.records[0].Error)
+	assert.Equal(t, OpDelete, r.records[5].Op)
+	assert.Equal(t, "/app", r.records[5].Path)
+	assert.NotEmpty(t, r.records[5].Error)
+	assert.Equal(t, OpCreate, r.records[6].Op)
+	assert.Equal(t, "/other/tx", r.records[6].Path)
+	assert.NotEmpty(t, r.records[6].Error)
+
+	cli.writePolicy = &WritePolicy{ReadOnly: true}
+	isPolicyError(cli.SetRawValue("/app/a", nil), ErrReadOnly)
+
+	_, err = cli.NewRWLock("/app/lock").Lock(context.Background())
+	isPolicyError(err, ErrReadOnly)
+
+	cli.writePolicy = nil
+	assert.Nil(t, cli.checkWrite("/any", nil))
+	assert.Nil(t, cli.checkDelete("/", true))
+	assert.Nil(t, cli.checkCreate("/any"))
+}
@@ -0,0 +1,327 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	queueItemsNode      = "items"
+	queueProcessingNode = "processing"
+	queueOwnerNode      = "owner"
+	queueItemPrefix     = "item-"
+
+	// MaxQueuePriority the max priority of priority queue, item of lower priority value is taken first
+	MaxQueuePriority = 999
+)
+
+var (
+	errNotPriorityQueue = errors.New("not priority queue")
+	errInvalidPriority  = fmt.Errorf("priority should between 0 and %d", MaxQueuePriority)
+)
+
+// Queue distributed queue based on sequential nodes.
+// Items are stored under the `items` node, a taken item is moved to the `processing` node
+// until it's acked, so that items taken by a dead consumer can be recovered (at-least-once).
+type Queue struct {
+	client         *Client
+	path           string
+	itemsPath      string
+	processingPath string
+	priority       bool
+}
+
+// QueueItem item taken or peeked from queue
+type QueueItem struct {
+	queue *Queue
+	Name  string
+	Data  []byte
+}
+
+// NewQueue create a distributed queue of the path
+func (cli *Client) NewQueue(path string) *Queue {
+	return &Queue{
+		client:         cli,
+		path:           path,
+		itemsPath:      PathJoin(path, queueItemsNode),
+		processingPath: PathJoin(path, queueProcessingNode),
+	}
+}
+
+// NewPriorityQueue create a distributed priority queue of the path, see OfferPriority
+func (cli *Client) NewPriorityQueue(path string) *Queue {
+	q := cli.NewQueue(path)
+	q.priority = true
+
+	return q
+}
+
+// Path of the queue
+func (q *Queue) Path() string {
+	return q.path
+}
+
+// Offer add object into the queue, priority queue uses the lowest priority, return the item name
+func (q *Queue) Offer(obj interface{}, codec Codec) (string, error) {
+	if q.priority {
+		return q.OfferPriority(obj, codec, MaxQueuePriority)
+	}
+
+	return q.offer(queueItemPrefix, obj, codec)
+}
+
+// OfferPriority add object into the priority queue, item of lower priority value is taken first
+func (q *Queue) OfferPriority(obj interface{}, codec Codec, priority int) (string, error) {
+	if !q.priority {
+		return "", errNotPriorityQueue
+	}
+
+	if priority < 0 || priority > MaxQueuePriority {
+		return "", errInvalidPriority
+	}
+
+	return q.offer(fmt.Sprintf("p%03d-", priority), obj, codec)
+}
+
+func (q *Queue) offer(prefix string, obj interface{}, codec Codec) (string, error) {
+	data, err := codec.Encode(obj)
+	if err != nil {
+		return "", err
+	}
+
+	prefixPath := PathJoin(q.itemsPath, prefix)
+
+	p, err := q.client.Create(prefixPath, data, zk.FlagSequence)
+	if err == zk.ErrNoNode {
+		if err = q.client.EnsurePath(q.itemsPath); err != nil {
+			return "", err
+		}
+
+		p, err = q.client.Create(prefixPath, data, zk.FlagSequence)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	q.client.log().Debug("zk queue offer", "path", q.path, "item", p)
+
+	return p[len(q.itemsPath)+1:], nil
+}
+
+// Peek return the head item without removing it, nil returned if the queue is empty
+func (q *Queue) Peek() (*QueueItem, error) {
+	children, err := q.items()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		data, _, err := q.client.Conn().Get(PathJoin(q.itemsPath, child))
+		if err == zk.ErrNoNode {
+			continue // taken by others
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &QueueItem{queue: q, Name: child, Data: data}, nil
+	}
+
+	return nil, nil
+}
+
+// Take remove the head item from the queue, and block until an item is available or the ctx is done.
+// The item must be acked after processed, otherwise it will be re-queued by Recover when the session expires.
+func (q *Queue) Take(ctx context.Context) (*QueueItem, error) {
+	if err := q.client.EnsurePathContext(ctx, q.processingPath); err != nil {
+		return nil, err
+	}
+
+	for {
+		children, _, ch, err := q.client.Conn().ChildrenW(q.itemsPath)
+		if err == zk.ErrNoNode {
+			if err = q.client.EnsurePathContext(ctx, q.itemsPath); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Strings(children)
+
+		for _, child := range children {
+			item, err := q.claim(child)
+			if err == zk.ErrNoNode || err == zk.ErrNodeExists || err == zk.ErrBadVersion {
+				continue // taken by others
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return item, nil
+		}
+
+		if err = q.client.waitEvent(ctx, ch); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// claim move the item into processing node in one transaction
+func (q *Queue) claim(child string) (*QueueItem, error) {
+	itemPath := PathJoin(q.itemsPath, child)
+
+	data, stat, err := q.client.Conn().Get(itemPath)
+	if err != nil {
+		return nil, err
+	}
+
+	processingPath := PathJoin(q.processingPath, child)
+
+	if err = q.client.multi(
+		&zk.DeleteRequest{Path: itemPath, Version: stat.Version},
+		&zk.CreateRequest{Path: processingPath, Data: data, Acl: zk.WorldACL(zk.PermAll)},
+		&zk.CreateRequest{Path: PathJoin(processingPath, queueOwnerNode), Acl: zk.WorldACL(zk.PermAll), Flags: zk.FlagEphemeral},
+	); err != nil {
+		return nil, err
+	}
+
+	q.client.log().Debug("zk queue take", "path", q.path, "item", child)
+
+	return &QueueItem{queue: q, Name: child, Data: data}, nil
+}
+
+// Recover re-queue items whose consumer session is lost, return the count of recovered items
+func (q *Queue) Recover() (int, error) {
+	children, _, err := q.client.Conn().Children(q.processingPath)
+	if err == zk.ErrNoNode {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for _, child := range children {
+		processingPath := PathJoin(q.processingPath, child)
+
+		exists, _, err := q.client.Conn().Exists(PathJoin(processingPath, queueOwnerNode))
+		if err != nil {
+			return count, err
+		}
+
+		if exists {
+			continue
+		}
+
+		data, stat, err := q.client.Conn().Get(processingPath)
+		if err == zk.ErrNoNode {
+			continue
+		}
+
+		if err != nil {
+			return count, err
+		}
+
+		err = q.client.multi(
+			&zk.DeleteRequest{Path: processingPath, Version: stat.Version},
+			&zk.CreateRequest{Path: PathJoin(q.itemsPath, child), Data: data, Acl: zk.WorldACL(zk.PermAll)},
+		)
+		if err == zk.ErrNoNode || err == zk.ErrNotEmpty || err == zk.ErrBadVersion {
+			continue // acked or recovered by others
+		}
+
+		if err == zk.ErrNodeExists {
+			// already recovered, remove the stale processing node
+			err = q.client.multi(&zk.DeleteRequest{Path: processingPath, Version: stat.Version})
+			if err != nil && err != zk.ErrNoNode {
+				q.client.log().Warn("zk queue failed to delete recovered item", "path", q.path, "item", child, "error", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return count, err
+		}
+
+		q.client.log().Info("zk queue recover", "path", q.path, "item", child)
+
+		count++
+	}
+
+	return count, nil
+}
+
+// items return sorted item names
+func (q *Queue) items() ([]string, error) {
+	children, _, err := q.client.Conn().Children(q.itemsPath)
+	if err == zk.ErrNoNode {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(children)
+
+	return children, nil
+}
+
+// Size the count of items waiting in the queue
+func (q *Queue) Size() (int, error) {
+	children, err := q.items()
+	return len(children), err
+}
+
+// Decode item data by codec
+func (i *QueueItem) Decode(codec Codec) (interface{}, error) {
+	return codec.Decode(i.Data)
+}
+
+// Ack remove the processed item, zk.ErrNoNode returned if the item was already acked,
+// or recovered for redelivery after the consumer session lost.
+func (i *QueueItem) Ack() error {
+	processingPath := PathJoin(i.queue.processingPath, i.Name)
+
+	err := i.queue.client.multi(
+		&zk.DeleteRequest{Path: PathJoin(processingPath, queueOwnerNode), Version: -1},
+		&zk.DeleteRequest{Path: processingPath, Version: -1},
+	)
+	if err != zk.ErrNoNode {
+		return err
+	}
+
+	// the owner node is gone with the expired session, remove the item if not recovered yet
+	return i.queue.client.multi(&zk.DeleteRequest{Path: processingPath, Version: -1})
+}
+
+// Release put the item back to the queue without processing it
+func (i *QueueItem) Release() error {
+	processingPath := PathJoin(i.queue.processingPath, i.Name)
+
+	return i.queue.client.multi(
+		&zk.DeleteRequest{Path: PathJoin(processingPath, queueOwnerNode), Version: -1},
+		&zk.DeleteRequest{Path: processingPath, Version: -1},
+		&zk.CreateRequest{Path: PathJoin(i.queue.itemsPath, i.Name), Data: i.Data, Acl: zk.WorldACL(zk.PermAll)},
+	)
+}
@@ -0,0 +1,63 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	q := testClient.NewPriorityQueue("/test/queue")
+
+	_, err := q.OfferPriority("low", stringCodec, 10)
+	assert.Nil(t, err)
+
+	_, err = q.OfferPriority("high", stringCodec, 1)
+	assert.Nil(t, err)
+
+	head, err := q.Peek()
+	assert.Nil(t, err)
+	assert.Equal(t, "high", string(head.Data))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	item, err := q.Take(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "high", string(item.Data))
+	assert.Nil(t, item.Release())
+
+	for _, expected := range []string{"high", "low"} {
+		item, err = q.Take(ctx)
+		assert.Nil(t, err)
+
+		obj, err := item.Decode(stringCodec)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, *obj.(*string))
+		assert.Nil(t, item.Ack())
+	}
+
+	size, err := q.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, size)
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer timeoutCancel()
+
+	_, err = q.Take(timeoutCtx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	_, err = testClient.NewQueue("/test/queue").OfferPriority("x", stringCodec, 1)
+	assert.Equal(t, errNotPriorityQueue, err)
+}
@@ -0,0 +1,183 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// rateLimitState tokens used in the window, stored in the shared node
+type rateLimitState struct {
+	Window int64 `json:"window"`
+	Used   int64 `json:"used"`
+}
+
+// RateLimiter coarse cluster-wide rate limiter, at most limit tokens are allowed in every window.
+// Instances lease batches of tokens from the shared node by CAS into a local bucket,
+// leased tokens not used within the window are wasted.
+type RateLimiter struct {
+	lock   sync.Mutex
+	client *Client
+	path   string
+	limit  int64
+	window time.Duration
+	batch  int64
+
+	// local bucket
+	tokens      int64
+	tokenWindow int64
+	exhausted   bool // no more tokens to lease in the token window
+}
+
+// NewRateLimiter create rate limiter of the path, allowing limit tokens per window, leasing batch tokens each time
+func (cli *Client) NewRateLimiter(path string, limit int, window time.Duration, batch int) (*RateLimiter, error) {
+	if limit <= 0 || batch <= 0 || window <= 0 {
+		return nil, errors.New("limit, window and batch should be positive")
+	}
+
+	if batch > limit {
+		batch = limit
+	}
+
+	return &RateLimiter{
+		client: cli,
+		path:   path,
+		limit:  int64(limit),
+		window: window,
+		batch:  int64(batch),
+	}, nil
+}
+
+// Allow take a token if available
+func (r *RateLimiter) Allow() (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	window := r.currentWindow()
+
+	if r.tokenWindow != window {
+		r.tokens = 0
+		r.tokenWindow = window
+		r.exhausted = false
+	}
+
+	if r.tokens == 0 && !r.exhausted {
+		granted, err := r.lease(window)
+		if err != nil {
+			return false, err
+		}
+
+		r.tokens = granted
+		r.exhausted = granted == 0
+	}
+
+	if r.tokens == 0 {
+		return false, nil
+	}
+
+	r.tokens--
+
+	return true, nil
+}
+
+// Wait until a token is available or the ctx is done
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		ok, err := r.Allow()
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.client.done:
+			return zk.ErrClosing
+		case <-time.After(time.Until(r.nextWindow())):
+		}
+	}
+}
+
+func (r *RateLimiter) currentWindow() int64 {
+	return time.Now().UnixNano() / int64(r.window)
+}
+
+// nextWindow the start time of the next window, aligned to the unix epoch same as currentWindow
+func (r *RateLimiter) nextWindow() time.Time {
+	return time.Unix(0, (r.currentWindow()+1)*int64(r.window))
+}
+
+// lease tokens of the window from the shared node, return the count of granted tokens
+func (r *RateLimiter) lease(window int64) (int64, error) {
+	for i := 0; i < counterMaxRetries; i++ {
+		data, stat, err := r.client.Conn().Get(r.path)
+		if err == zk.ErrNoNode {
+			if err = r.client.EnsurePath(r.path); err != nil && err != zk.ErrNodeExists {
+				return 0, err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		state := &rateLimitState{}
+		if len(data) > 0 {
+			if err = json.Unmarshal(data, state); err != nil {
+				return 0, err
+			}
+		}
+
+		if state.Window > window {
+			return 0, nil // local clock behind others
+		}
+
+		if state.Window < window {
+			state.Window = window
+			state.Used = 0
+		}
+
+		granted := r.limit - state.Used
+		if granted > r.batch {
+			granted = r.batch
+		}
+
+		if granted <= 0 {
+			return 0, nil
+		}
+
+		state.Used += granted
+
+		if data, err = json.Marshal(state); err != nil {
+			return 0, err
+		}
+
+		err = r.client.SetRawValueVersion(r.path, data, stat.Version)
+		if err == zk.ErrBadVersion {
+			continue
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		return granted, nil
+	}
+
+	return 0, zk.ErrBadVersion
+}
@@ -0,0 +1,74 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	path := "/test/rate_limit"
+	_ = testClient.Delete(path)
+
+	r1, err := testClient.NewRateLimiter(path, 10, time.Hour, 4)
+	assert.Nil(t, err)
+
+	r2, err := testClient.NewRateLimiter(path, 10, time.Hour, 4)
+	assert.Nil(t, err)
+
+	allowed := 0
+
+	for i := 0; i < 10; i++ {
+		for _, r := range []*RateLimiter{r1, r2} {
+			ok, err := r.Allow()
+			assert.Nil(t, err)
+
+			if ok {
+				allowed++
+			}
+		}
+	}
+
+	assert.Equal(t, 10, allowed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, r1.Wait(ctx))
+}
+
+func TestRateLimiterNextWindow(t *testing.T) {
+	r, err := (&Client{}).NewRateLimiter("/test/rate_limit", 10, time.Second*7, 4)
+	assert.Nil(t, err)
+
+	now := time.Now()
+	next := r.nextWindow()
+
+	assert.True(t, next.After(now))
+	assert.True(t, next.Sub(now) <= r.window)
+	assert.Equal(t, int64(0), next.UnixNano()%int64(r.window))
+}
+
+func TestRateLimiterExhausted(t *testing.T) {
+	r, err := (&Client{}).NewRateLimiter("/test/rate_limit", 10, time.Hour, 4)
+	assert.Nil(t, err)
+
+	// no lease from the shared node until the window changes
+	r.tokenWindow = r.currentWindow()
+	r.exhausted = true
+
+	ok, err := r.Allow()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
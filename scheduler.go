@@ -0,0 +1,302 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	jobHistoryNode = "history"
+	jobRunningNode = "running-"
+
+	// JobRunning status of a running job
+	JobRunning = "running"
+	// JobSuccess status of a job finished successfully
+	JobSuccess = "success"
+	// JobFailed status of a job finished with error
+	JobFailed = "failed"
+
+	defaultJobHistoryLimit = 10
+)
+
+// JobFunc function of a scheduled job
+type JobFunc func(ctx context.Context) error
+
+// JobRun record of a job run, stored in the job node (the last run) and the history nodes
+type JobRun struct {
+	Job      string `json:"job"`
+	FireTime int64  `json:"fireTime"`
+	Runner   string `json:"runner"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Start    int64  `json:"start"`
+	Duration int64  `json:"duration"`
+}
+
+// Scheduler run cron jobs registered on all instances, but only one instance runs a job for each fire time.
+// The runner of a fire time is elected by creating the history node of the fire time together with an ephemeral running node.
+type Scheduler struct {
+	lock         sync.Mutex
+	client       *Client
+	path         string
+	runner       string
+	historyLimit int
+	jobs         map[string]context.CancelFunc
+}
+
+// NewScheduler create scheduler storing jobs under the path, the hostname and pid are used as runner if empty
+func (cli *Client) NewScheduler(path, runner string) *Scheduler {
+	if runner == "" {
+		host, _ := os.Hostname()
+		runner = host + "-" + strconv.Itoa(os.Getpid())
+	}
+
+	return &Scheduler{
+		client:       cli,
+		path:         path,
+		runner:       runner,
+		historyLimit: defaultJobHistoryLimit,
+		jobs:         make(map[string]context.CancelFunc),
+	}
+}
+
+// SetHistoryLimit set the max count of history records kept for each job
+func (s *Scheduler) SetHistoryLimit(limit int) {
+	s.historyLimit = limit
+}
+
+// Register schedule the job of the cron spec, see ParseSchedule
+func (s *Scheduler) Register(name, spec string, job JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s already registered", name)
+	}
+
+	if err = s.client.EnsurePath(s.jobPath(name, jobHistoryNode)); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.jobs[name] = cancel
+
+	go s.loop(ctx, name, schedule, job)
+
+	return nil
+}
+
+// Unregister stop scheduling the job
+func (s *Scheduler) Unregister(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if cancel, ok := s.jobs[name]; ok {
+		cancel()
+		delete(s.jobs, name)
+	}
+}
+
+// Stop all jobs
+func (s *Scheduler) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for name, cancel := range s.jobs {
+		cancel()
+		delete(s.jobs, name)
+	}
+}
+
+// LastRun return the last run record of the job
+func (s *Scheduler) LastRun(name string) (*JobRun, error) {
+	run, err := s.client.Get(s.jobPath(name), jobRunCodec())
+	if err != nil {
+		return nil, err
+	}
+
+	return run.(*JobRun), nil
+}
+
+// History return run records of the job, sorted by fire time
+func (s *Scheduler) History(name string) ([]*JobRun, error) {
+	historyPath := s.jobPath(name, jobHistoryNode)
+
+	children, err := s.client.GetChildren(historyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(children)
+
+	runs := make([]*JobRun, 0, len(children))
+	codec := jobRunCodec()
+
+	for _, child := range children {
+		run, err := s.client.Get(PathJoin(historyPath, child), codec)
+		if err == zk.ErrNoNode {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, run.(*JobRun))
+	}
+
+	return runs, nil
+}
+
+func (s *Scheduler) jobPath(name string, nodes ...string) string {
+	return PathJoin(append([]string{s.path, name}, nodes...)...)
+}
+
+func (s *Scheduler) loop(ctx context.Context, name string, schedule Schedule, job JobFunc) {
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			s.client.log().Warn("zk scheduler job has no more fire time", "job", name)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.client.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.fire(ctx, name, next, job)
+	}
+}
+
+// fire run the job if this instance is elected as the runner of the fire time
+func (s *Scheduler) fire(ctx context.Context, name string, fireTime time.Time, job JobFunc) {
+	fire := strconv.FormatInt(fireTime.Unix(), 10)
+	historyPath := s.jobPath(name, jobHistoryNode, fire)
+	runningPath := s.jobPath(name, jobRunningNode+fire)
+
+	run := &JobRun{
+		Job:      name,
+		FireTime: fireTime.Unix(),
+		Runner:   s.runner,
+		Status:   JobRunning,
+		Start:    time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	data, err := jobRunCodec().Encode(run)
+	if err != nil {
+		s.client.log().Error("zk scheduler job encode error", "job", name, "error", err)
+		return
+	}
+
+	err = s.client.multi(
+		&zk.CreateRequest{Path: runningPath, Data: []byte(s.runner), Acl: zk.WorldACL(zk.PermAll), Flags: zk.FlagEphemeral},
+		&zk.CreateRequest{Path: historyPath, Data: data, Acl: zk.WorldACL(zk.PermAll)},
+	)
+	if err == zk.ErrNodeExists {
+		s.client.log().Debug("zk scheduler job run by others", "job", name, "fire", fire)
+		return
+	}
+
+	if err != nil {
+		s.client.log().Error("zk scheduler job elect error", "job", name, "fire", fire, "error", err)
+		return
+	}
+
+	s.client.log().Info("zk scheduler job start", "job", name, "fire", fire)
+
+	err = s.runJob(ctx, job)
+
+	run.Duration = time.Now().UnixNano()/int64(time.Millisecond) - run.Start
+	run.Status = JobSuccess
+
+	if err != nil {
+		run.Status = JobFailed
+		run.Error = err.Error()
+	}
+
+	s.client.log().Info("zk scheduler job finished", "job", name, "fire", fire, "status", run.Status, "duration_ms", run.Duration)
+
+	s.record(name, historyPath, run)
+
+	if err = s.client.Delete(runningPath); err != nil {
+		s.client.log().Warn("zk scheduler job failed to delete running node", "job", name, "path", runningPath, "error", err)
+	}
+
+	s.pruneHistory(name)
+}
+
+// runJob run the job, and recover the panic as error
+func (s *Scheduler) runJob(ctx context.Context, job JobFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+
+	return job(ctx)
+}
+
+// record set the run into the history node and the job node
+func (s *Scheduler) record(name, historyPath string, run *JobRun) {
+	data, err := jobRunCodec().Encode(run)
+	if err != nil {
+		s.client.log().Error("zk scheduler job encode error", "job", name, "error", err)
+		return
+	}
+
+	if err = s.client.SetRawValue(historyPath, data); err != nil {
+		s.client.log().Warn("zk scheduler job failed to record history", "job", name, "path", historyPath, "error", err)
+	}
+
+	if err = s.client.SetRawValue(s.jobPath(name), data); err != nil {
+		s.client.log().Warn("zk scheduler job failed to record last run", "job", name, "error", err)
+	}
+}
+
+// pruneHistory delete the oldest history records exceeding the limit
+func (s *Scheduler) pruneHistory(name string) {
+	historyPath := s.jobPath(name, jobHistoryNode)
+
+	children, err := s.client.GetChildren(historyPath)
+	if err != nil || len(children) <= s.historyLimit {
+		return
+	}
+
+	sort.Strings(children)
+
+	for _, child := range children[:len(children)-s.historyLimit] {
+		if err := s.client.Delete(PathJoin(historyPath, child)); err != nil {
+			s.client.log().Warn("zk scheduler job failed to prune history", "job", name, "path", PathJoin(historyPath, child), "error", err)
+		}
+	}
+}
+
+func jobRunCodec() Codec {
+	return &JSONCodec{typ: reflect.TypeOf(JobRun{})}
+}
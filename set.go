@@ -6,8 +6,9 @@
 package zkclient
 
 import (
+	"context"
+
 	"github.com/samuel/go-zookeeper/zk"
-	"github.com/vogo/logger"
 )
 
 // SetValue set value in zookeeper
@@ -22,17 +23,78 @@ func (cli *Client) SetValue(path string, obj interface{}, codec Codec) error {
 
 // SetRawValue set raw value in zookeeper
 func (cli *Client) SetRawValue(path string, bytes []byte) error {
-	logger.Debugf("zk set node [%s]", path)
+	return cli.SetRawValueContext(context.Background(), path, bytes)
+}
 
-	if err := cli.EnsurePath(path); err != nil {
-		return err
+// SetRawValueContext set raw value, the operation is traced as a child span of the ctx
+func (cli *Client) SetRawValueContext(ctx context.Context, path string, bytes []byte) (err error) {
+	cli.log().Debug("zk set node", "path", path)
+
+	_, o := cli.startOperation(ctx, OpSet, path)
+	defer func() { o.end(err) }()
+
+	newVersion, err := cli.setRawValue(path, bytes, -1, true)
+	if err == nil {
+		o.version(newVersion)
 	}
 
-	if _, err := cli.Conn().Set(path, bytes, -1); err != nil {
-		return err
+	cli.audit(ctx, OpSet, path, replacedVersion(newVersion), newVersion, bytes, err)
+
+	return err
+}
+
+// SetRawValueVersion set raw value in zookeeper only when the node version matches,
+// zk.ErrBadVersion returned if the node was changed by others.
+func (cli *Client) SetRawValueVersion(path string, bytes []byte, version int32) error {
+	return cli.SetRawValueVersionContext(context.Background(), path, bytes, version)
+}
+
+// SetRawValueVersionContext set raw value only when the node version matches,
+// the operation is traced as a child span of the ctx.
+func (cli *Client) SetRawValueVersionContext(ctx context.Context, path string, bytes []byte, version int32) (err error) {
+	cli.log().Debug("zk set node", "path", path, "version", version)
+
+	_, o := cli.startOperation(ctx, OpSet, path)
+	defer func() { o.end(err) }()
+
+	newVersion, err := cli.setRawValue(path, bytes, version, false)
+	if err == nil {
+		o.version(newVersion)
 	}
 
-	return nil
+	cli.audit(ctx, OpSet, path, version, newVersion, bytes, err)
+
+	return err
+}
+
+// setRawValue check the write policy and set the value, return the new version,
+// the node is created if not exists when ensure.
+func (cli *Client) setRawValue(path string, bytes []byte, version int32, ensure bool) (int32, error) {
+	if err := cli.checkWrite(path, bytes); err != nil {
+		return noVersion, err
+	}
+
+	if cli.chunkSize > 0 {
+		return cli.setChunkedValue(path, bytes, version)
+	}
+
+	if ensure {
+		if err := cli.ensurePath(path); err != nil {
+			return noVersion, err
+		}
+	}
+
+	return cli.setValue(path, bytes, version)
+}
+
+// setValue set value of the node, return the new version
+func (cli *Client) setValue(path string, bytes []byte, version int32) (int32, error) {
+	stat, err := cli.Conn().Set(path, bytes, version)
+	if err != nil {
+		return noVersion, err
+	}
+
+	return stat.Version, nil
 }
 
 // SetString in zookeeper
@@ -67,15 +129,56 @@ func (cli *Client) SetMapJSONValue(path, key string, obj interface{}) error {
 	return cli.SetMapValue(path, key, obj, jsonEncodeCodec)
 }
 
+// Create node of the flags with raw value in zookeeper, return the created path
+func (cli *Client) Create(path string, bytes []byte, flags int32) (string, error) {
+	return cli.CreateContext(context.Background(), path, bytes, flags)
+}
+
+// CreateContext create node of the flags with raw value, return the created path,
+// the operation is traced as a child span of the ctx
+func (cli *Client) CreateContext(ctx context.Context, path string, bytes []byte, flags int32) (string, error) {
+	_, o := cli.startOperation(ctx, OpCreate, path)
+
+	created := path
+
+	err := cli.checkWrite(path, bytes)
+	if err == nil {
+		created, err = cli.Conn().Create(path, bytes, flags, zk.WorldACL(zk.PermAll))
+	}
+
+	o.end(err)
+
+	// nothing written if the node exists or the parent not exists
+	if err != zk.ErrNodeExists && err != zk.ErrNoNode {
+		if created == "" {
+			created = path
+		}
+
+		cli.audit(ctx, OpCreate, created, noVersion, 0, bytes, err)
+	}
+
+	return created, err
+}
+
 // CreateTempRawValue create temp raw value in zookeeper
 func (cli *Client) CreateTempRawValue(path string, bytes []byte) error {
-	_, err := cli.Conn().Create(path, bytes, zk.FlagEphemeral, zk.WorldACL(zk.PermAll))
+	return cli.CreateTempRawValueContext(context.Background(), path, bytes)
+}
+
+// CreateTempRawValueContext create temp raw value, the operation is traced as a child span of the ctx
+func (cli *Client) CreateTempRawValueContext(ctx context.Context, path string, bytes []byte) error {
+	_, err := cli.CreateContext(ctx, path, bytes, zk.FlagEphemeral)
 	return err
 }
 
 // SetTempRawValue set temp raw value in zookeeper
 func (cli *Client) SetTempRawValue(path string, bytes []byte) error {
-	err := cli.CreateTempRawValue(path, bytes)
+	return cli.SetTempRawValueContext(context.Background(), path, bytes)
+}
+
+// SetTempRawValueContext set temp raw value, the operation is traced as a child span of the ctx
+func (cli *Client) SetTempRawValueContext(ctx context.Context, path string, bytes []byte) error {
+	err := cli.CreateTempRawValueContext(ctx, path, bytes)
 	if err == nil {
 		return nil
 	}
@@ -84,7 +187,16 @@ func (cli *Client) SetTempRawValue(path string, bytes []byte) error {
 		return err
 	}
 
-	_, err = cli.Conn().Set(path, bytes, -1)
+	_, o := cli.startOperation(ctx, OpSet, path)
+	newVersion, err := cli.setValue(path, bytes, -1)
+
+	if err == nil {
+		o.version(newVersion)
+	}
+
+	o.end(err)
+
+	cli.audit(ctx, OpSet, path, replacedVersion(newVersion), newVersion, bytes, err)
 
 	return err
 }
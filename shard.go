@@ -0,0 +1,350 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// ShardAssignment partitions assigned to members, published in the assignment node
+type ShardAssignment struct {
+	MemberVersion int32            `json:"memberVersion"`
+	Partitions    int              `json:"partitions"`
+	Assignments   map[string][]int `json:"assignments"`
+}
+
+// ShardStrategy compute partitions assignment for sorted members
+type ShardStrategy interface {
+	Assign(members []string, partitions int) map[string][]int
+}
+
+// ShardStrategyFunc function shard strategy
+type ShardStrategyFunc func(members []string, partitions int) map[string][]int
+
+// Assign call the function
+func (f ShardStrategyFunc) Assign(members []string, partitions int) map[string][]int {
+	return f(members, partitions)
+}
+
+var (
+	// RangeShardStrategy assign contiguous ranges of partitions to members
+	RangeShardStrategy ShardStrategy = ShardStrategyFunc(assignRange)
+
+	// RoundRobinShardStrategy assign partitions to members one by one
+	RoundRobinShardStrategy ShardStrategy = ShardStrategyFunc(assignRoundRobin)
+)
+
+func assignRange(members []string, partitions int) map[string][]int {
+	assignments := make(map[string][]int, len(members))
+	if len(members) == 0 {
+		return assignments
+	}
+
+	size, extra := partitions/len(members), partitions%len(members)
+	start := 0
+
+	for i, member := range members {
+		end := start + size
+		if i < extra {
+			end++
+		}
+
+		for p := start; p < end; p++ {
+			assignments[member] = append(assignments[member], p)
+		}
+
+		start = end
+	}
+
+	return assignments
+}
+
+func assignRoundRobin(members []string, partitions int) map[string][]int {
+	assignments := make(map[string][]int, len(members))
+	if len(members) == 0 {
+		return assignments
+	}
+
+	for p := 0; p < partitions; p++ {
+		member := members[p%len(members)]
+		assignments[member] = append(assignments[member], p)
+	}
+
+	return assignments
+}
+
+// ConsistentHashShardStrategy assign partitions by consistent hashing,
+// so that only partitions of the joined or left member move.
+type ConsistentHashShardStrategy struct {
+	// Replicas virtual nodes of each member on the hash ring
+	Replicas int
+}
+
+// Assign partitions to the nearest member on the hash ring
+func (s *ConsistentHashShardStrategy) Assign(members []string, partitions int) map[string][]int {
+	assignments := make(map[string][]int, len(members))
+	if len(members) == 0 {
+		return assignments
+	}
+
+	replicas := s.Replicas
+	if replicas <= 0 {
+		replicas = 1
+	}
+
+	ring := make([]uint32, 0, len(members)*replicas)
+	owners := make(map[uint32]string, len(members)*replicas)
+
+	for _, member := range members {
+		for i := 0; i < replicas; i++ {
+			h := hashKey(member + "#" + strconv.Itoa(i))
+			ring = append(ring, h)
+			owners[h] = member
+		}
+	}
+
+	sort.Slice(ring, func(i, j int) bool { return ring[i] < ring[j] })
+
+	for p := 0; p < partitions; p++ {
+		h := hashKey(strconv.Itoa(p))
+
+		idx := sort.Search(len(ring), func(i int) bool { return ring[i] >= h })
+		if idx == len(ring) {
+			idx = 0
+		}
+
+		member := owners[ring[idx]]
+		assignments[member] = append(assignments[member], p)
+	}
+
+	return assignments
+}
+
+func hashKey(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	return h.Sum32()
+}
+
+// ShardListener listen partitions assignment changes of the member
+type ShardListener interface {
+	Rebalance(member string, gained, lost, current []int)
+}
+
+// ShardAssigner split partitions among live members of the member path.
+// Every assigner computes the assignment when members change and publishes it into the assignment node by CAS,
+// and notifies its member the gained and lost partitions when the published assignment changes.
+type ShardAssigner struct {
+	lock          sync.Mutex
+	client        *Client
+	memberPath    string
+	assignPath    string
+	member        string
+	partitions    int
+	strategy      ShardStrategy
+	listener      ShardListener
+	assignment    *ShardAssignment
+	current       []int
+	memberWatcher *Watcher
+	assignWatcher *Watcher
+}
+
+// NewShardAssigner create shard assigner for the member, and start watching members and assignment
+func (cli *Client) NewShardAssigner(memberPath, assignPath, member string, partitions int,
+	strategy ShardStrategy, listener ShardListener) (*ShardAssigner, error) {
+	if memberPath == "" || assignPath == "" || member == "" {
+		return nil, errors.New("member path, assign path and member required")
+	}
+
+	if partitions <= 0 {
+		return nil, errors.New("partitions should be positive")
+	}
+
+	if strategy == nil {
+		strategy = RangeShardStrategy
+	}
+
+	a := &ShardAssigner{
+		client:     cli,
+		memberPath: memberPath,
+		assignPath: assignPath,
+		member:     member,
+		partitions: partitions,
+		strategy:   strategy,
+		listener:   listener,
+		assignment: &ShardAssignment{},
+	}
+
+	var err error
+
+	if a.assignWatcher, err = cli.SyncWatch(assignPath, a.assignment, &JSONCodec{}, &shardAssignListener{assigner: a}); err != nil {
+		return nil, err
+	}
+
+	if a.memberWatcher, err = cli.createWatcher(&shardMemberHandler{assigner: a}); err != nil {
+		a.assignWatcher.Close()
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Partitions current partitions of the member
+func (a *ShardAssigner) Partitions() []int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return append([]int(nil), a.current...)
+}
+
+// Close stop watching
+func (a *ShardAssigner) Close() {
+	a.memberWatcher.Close()
+	a.assignWatcher.Close()
+}
+
+// publish compute the assignment for the members, and publish it if newer than the current one
+func (a *ShardAssigner) publish(members []string, memberVersion int32) error {
+	sort.Strings(members)
+
+	assignment := &ShardAssignment{
+		MemberVersion: memberVersion,
+		Partitions:    a.partitions,
+		Assignments:   a.strategy.Assign(members, a.partitions),
+	}
+
+	data, err := json.Marshal(assignment)
+	if err != nil {
+		return err
+	}
+
+	for {
+		current, stat, err := a.client.Conn().Get(a.assignPath)
+		if err == zk.ErrNoNode {
+			if err = a.client.EnsurePath(a.assignPath); err != nil && err != zk.ErrNodeExists {
+				return err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(current, data) {
+			return nil
+		}
+
+		published := &ShardAssignment{}
+		if len(current) > 0 && json.Unmarshal(current, published) == nil &&
+			published.Partitions == a.partitions && published.MemberVersion > memberVersion {
+			return nil // published by others who saw newer members
+		}
+
+		err = a.client.SetRawValueVersion(a.assignPath, data, stat.Version)
+		if err == zk.ErrBadVersion {
+			continue
+		}
+
+		if err == nil {
+			a.client.log().Info("zk shard assigner publish assignment", "path", a.assignPath, "members", len(members))
+		}
+
+		return err
+	}
+}
+
+// update notify the member if its partitions changed
+func (a *ShardAssigner) update(assignment *ShardAssignment) {
+	a.lock.Lock()
+
+	partitions := append([]int(nil), assignment.Assignments[a.member]...)
+	gained, lost := diffPartitions(a.current, partitions)
+	a.current = partitions
+
+	a.lock.Unlock()
+
+	if a.listener != nil && len(gained)+len(lost) > 0 {
+		a.listener.Rebalance(a.member, gained, lost, partitions)
+	}
+}
+
+// diffPartitions return partitions in current but not in previous, and partitions in previous but not in current
+func diffPartitions(previous, current []int) (gained, lost []int) {
+	old := make(map[int]struct{}, len(previous))
+	for _, p := range previous {
+		old[p] = nilStruct
+	}
+
+	for _, p := range current {
+		if _, ok := old[p]; ok {
+			delete(old, p)
+		} else {
+			gained = append(gained, p)
+		}
+	}
+
+	for p := range old {
+		lost = append(lost, p)
+	}
+
+	sort.Ints(lost)
+
+	return gained, lost
+}
+
+// shardMemberHandler publish assignment when members change
+type shardMemberHandler struct {
+	assigner *ShardAssigner
+}
+
+func (h *shardMemberHandler) Path() string {
+	return h.assigner.memberPath
+}
+
+func (h *shardMemberHandler) Handle(w *Watcher, _ *zk.Event) (<-chan zk.Event, error) {
+	members, stat, ch, err := w.client.Conn().ChildrenW(h.assigner.memberPath)
+	if err == zk.ErrNoNode {
+		if err = w.client.EnsurePath(h.assigner.memberPath); err != nil {
+			return nil, err
+		}
+
+		members, stat, ch, err = w.client.Conn().ChildrenW(h.assigner.memberPath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.assigner.publish(members, stat.Cversion); err != nil {
+		w.client.log().Warn("zk shard assigner failed to publish assignment", "path", h.assigner.assignPath, "error", err)
+	}
+
+	return ch, nil
+}
+
+// shardAssignListener notify the assigner when the assignment changes
+type shardAssignListener struct {
+	assigner *ShardAssigner
+}
+
+func (l *shardAssignListener) Update(_ string, _ *zk.Stat, obj interface{}) {
+	l.assigner.update(obj.(*ShardAssignment))
+}
+
+func (l *shardAssignListener) Delete(string) {
+	l.assigner.update(&ShardAssignment{})
+}
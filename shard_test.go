@@ -0,0 +1,48 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardStrategy(t *testing.T) {
+	members := []string{"m1", "m2", "m3"}
+
+	assignments := RangeShardStrategy.Assign(members, 8)
+	assert.Equal(t, []int{0, 1, 2}, assignments["m1"])
+	assert.Equal(t, []int{3, 4, 5}, assignments["m2"])
+	assert.Equal(t, []int{6, 7}, assignments["m3"])
+
+	assignments = RoundRobinShardStrategy.Assign(members, 8)
+	assert.Equal(t, []int{0, 3, 6}, assignments["m1"])
+	assert.Equal(t, []int{2, 5}, assignments["m3"])
+
+	hashing := &ConsistentHashShardStrategy{Replicas: 16}
+	before := hashing.Assign(members, 64)
+	after := hashing.Assign(members[:2], 64)
+
+	total := 0
+	for _, member := range members[:2] {
+		total += len(after[member])
+
+		// partitions of remaining members don't move
+		gained, lost := diffPartitions(before[member], after[member])
+		assert.Equal(t, 0, len(lost))
+		assert.Equal(t, len(after[member])-len(before[member]), len(gained))
+	}
+
+	assert.Equal(t, 64, total)
+	assert.Equal(t, 0, len(RangeShardStrategy.Assign(nil, 8)))
+}
+
+func TestDiffPartitions(t *testing.T) {
+	gained, lost := diffPartitions([]int{1, 2, 3}, []int{2, 3, 4, 5})
+	assert.Equal(t, []int{4, 5}, gained)
+	assert.Equal(t, []int{1}, lost)
+}
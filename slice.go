@@ -0,0 +1,170 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// sequenceLength the length of the sequence suffix of sequential node
+const sequenceLength = 10
+
+// SliceListener listen items inserted into or removed from a synchronized slice,
+// an updated item is removed from its old index and inserted at its new index.
+type SliceListener interface {
+	Insert(path string, index int, child string, obj interface{})
+	Remove(path string, index int, child string, obj interface{})
+}
+
+// ChildLess report whether child a should sort before child b
+type ChildLess func(a, b *ChildEvent) bool
+
+// SequenceLess sort children by the sequence number suffix, then by name
+func SequenceLess(a, b *ChildEvent) bool {
+	seqA, okA := ParseSequence(a.Child)
+	seqB, okB := ParseSequence(b.Child)
+
+	if okA && okB && seqA != seqB {
+		return seqA < seqB
+	}
+
+	if okA != okB {
+		return okA
+	}
+
+	return a.Child < b.Child
+}
+
+// ParseSequence parse the sequence number suffix of sequential node name
+func ParseSequence(node string) (int64, bool) {
+	if len(node) < sequenceLength {
+		return 0, false
+	}
+
+	seq, err := strconv.ParseInt(node[len(node)-sequenceLength:], 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false
+	}
+
+	return seq, true
+}
+
+// sliceBinder bind children of a map handler into a sorted slice
+type sliceBinder struct {
+	lock        sync.Mutex
+	path        string
+	value       reflect.Value
+	less        ChildLess
+	listenAsync bool
+	listener    SliceListener
+	items       []*ChildEvent
+}
+
+func (cli *Client) newSliceHandler(path string, s interface{}, syncChild bool, codec Codec,
+	less ChildLess, listener SliceListener, options []SyncOption) (*mapHandler, error) {
+	typ := reflect.TypeOf(s)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Slice {
+		return nil, errors.New("slice pointer required")
+	}
+
+	if less == nil {
+		less = SequenceLess
+	}
+
+	binder := &sliceBinder{
+		path:        path,
+		value:       reflect.ValueOf(s),
+		less:        less,
+		listenAsync: cli.listenAsync,
+		listener:    listener,
+	}
+
+	// the map handler validates the element type and loads children
+	m := reflect.MakeMap(reflect.MapOf(reflect.TypeOf(""), typ.Elem().Elem())).Interface()
+
+	handler, err := cli.newMapHandler(path, m, syncChild, codec, true, binder, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the order of changes, the slice listener is called asynchronously if required
+	handler.listenAsync = false
+
+	return handler, nil
+}
+
+// Update insert or replace the child item
+func (b *sliceBinder) Update(_, child string, stat *zk.Stat, obj interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.remove(child)
+
+	item := &ChildEvent{Child: child, Stat: stat, Value: obj}
+	index := sort.Search(len(b.items), func(i int) bool {
+		return b.less(item, b.items[i])
+	})
+
+	b.items = append(b.items, nil)
+	copy(b.items[index+1:], b.items[index:])
+	b.items[index] = item
+
+	b.bind()
+
+	if b.listener != nil {
+		callListener(b.listenAsync, func() {
+			b.listener.Insert(b.path, index, child, obj)
+		})
+	}
+}
+
+// Delete remove the child item
+func (b *sliceBinder) Delete(_, child string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.remove(child) {
+		b.bind()
+	}
+}
+
+// remove the child item if exists, must be called with lock held
+func (b *sliceBinder) remove(child string) bool {
+	for index, item := range b.items {
+		if item.Child != child {
+			continue
+		}
+
+		b.items = append(b.items[:index], b.items[index+1:]...)
+
+		if b.listener != nil {
+			callListener(b.listenAsync, func() {
+				b.listener.Remove(b.path, index, child, item.Value)
+			})
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// bind set the sorted items into the slice
+func (b *sliceBinder) bind() {
+	slice := reflect.MakeSlice(b.value.Elem().Type(), len(b.items), len(b.items))
+
+	for i, item := range b.items {
+		slice.Index(i).Set(reflect.ValueOf(item.Value))
+	}
+
+	b.value.Elem().Set(slice)
+}
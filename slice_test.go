@@ -0,0 +1,53 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSequence(t *testing.T) {
+	seq, ok := ParseSequence("item-0000000012")
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), seq)
+
+	_, ok = ParseSequence("item")
+	assert.False(t, ok)
+
+	assert.True(t, SequenceLess(&ChildEvent{Child: "b-0000000002"}, &ChildEvent{Child: "a-0000000010"}))
+	assert.True(t, SequenceLess(&ChildEvent{Child: "z-0000000002"}, &ChildEvent{Child: "a"}))
+}
+
+func TestSyncSliceBinder(t *testing.T) {
+	cli := &Client{}
+
+	var users []*user
+
+	h, err := cli.newSliceHandler("/test/user_list", &users, true, &JSONCodec{}, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Nil(t, h.Decode(&zk.Stat{}, "u-0000000002", []byte(`{"name":"jack"}`)))
+	assert.Nil(t, h.Decode(&zk.Stat{}, "u-0000000001", []byte(`{"name":"wongoo"}`)))
+	assert.Nil(t, h.Decode(&zk.Stat{}, "u-0000000003", []byte(`{"name":"yang"}`)))
+
+	assert.Equal(t, 3, len(users))
+	assert.Equal(t, "wongoo", users[0].Name)
+	assert.Equal(t, "jack", users[1].Name)
+	assert.Equal(t, "yang", users[2].Name)
+
+	h.Delete("u-0000000001")
+	assert.Nil(t, h.Decode(&zk.Stat{}, "u-0000000003", []byte(`{"name":"rose"}`)))
+
+	assert.Equal(t, 2, len(users))
+	assert.Equal(t, "jack", users[0].Name)
+	assert.Equal(t, "rose", users[1].Name)
+
+	_, err = cli.newSliceHandler("/test/user_list", users, true, &JSONCodec{}, nil, nil, nil)
+	assert.NotNil(t, err)
+}
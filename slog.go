@@ -0,0 +1,25 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+//go:build go1.21
+// +build go1.21
+
+package zkclient
+
+import (
+	"log/slog"
+)
+
+var _ Logger = (*slog.Logger)(nil)
+
+// NewSlogLogger create logger writing to the slog logger, slog.Default() is used if nil.
+// The zookeeper connection logs are written at debug level with the session id.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return logger.With("component", "zkclient")
+}
@@ -0,0 +1,48 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+//go:build go1.21
+// +build go1.21
+
+package zkclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	cli := &Client{}
+	cli.logger = NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	options := newSyncOptions(nil)
+	options.reportError(cli, "/a", errInvalidTest)
+
+	l := &connLogger{logger: cli.logger}
+	l.Printf("connected to %s", "127.0.0.1:2181")
+
+	dec := json.NewDecoder(&buf)
+
+	var record map[string]interface{}
+
+	assert.Nil(t, dec.Decode(&record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "zk failed to parse", record["msg"])
+	assert.Equal(t, "/a", record["path"])
+	assert.Equal(t, errInvalidTest.Error(), record["error"])
+	assert.Equal(t, "zkclient", record["component"])
+
+	record = nil
+	assert.Nil(t, dec.Decode(&record))
+	assert.Equal(t, "DEBUG", record["level"])
+	assert.Equal(t, "zk connected to 127.0.0.1:2181", record["msg"])
+	assert.Equal(t, float64(0), record["session"])
+}
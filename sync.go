@@ -6,13 +6,13 @@
 package zkclient
 
 // Sync synchronize value of the path to obj
-func (cli *Client) Sync(path string, obj interface{}, codec Codec) (*Watcher, error) {
-	return cli.SyncWatch(path, obj, codec, nil)
+func (cli *Client) Sync(path string, obj interface{}, codec Codec, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatch(path, obj, codec, nil, options...)
 }
 
 // SyncWatch synchronize value of the path to obj, and trigger listener when value change
-func (cli *Client) SyncWatch(path string, obj interface{}, codec Codec, listener ValueListener) (*Watcher, error) {
-	handler, err := cli.newValueHandler(path, obj, codec, false, listener)
+func (cli *Client) SyncWatch(path string, obj interface{}, codec Codec, listener ValueListener, options ...SyncOption) (*Watcher, error) {
+	handler, err := cli.newValueHandler(path, obj, codec, false, listener, options)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +21,8 @@ func (cli *Client) SyncWatch(path string, obj interface{}, codec Codec, listener
 }
 
 // Watch synchronize value of the path to obj, and trigger listener when value change
-func (cli *Client) Watch(path string, obj interface{}, codec Codec, listener ValueListener) (*Watcher, error) {
-	handler, err := cli.newValueHandler(path, obj, codec, true, listener)
+func (cli *Client) Watch(path string, obj interface{}, codec Codec, listener ValueListener, options ...SyncOption) (*Watcher, error) {
+	handler, err := cli.newValueHandler(path, obj, codec, true, listener, options)
 	if err != nil {
 		return nil, err
 	}
@@ -37,39 +37,47 @@ func (cli *Client) createWatcher(handler EventHandler) (*Watcher, error) {
 		return nil, err
 	}
 
+	// stop coalescing child changes once closed
+	if h, ok := handler.(*mapHandler); ok {
+		if c, ok := h.listener.(*childCoalescer); ok {
+			c.closeOn(watcher.Done(), cli.done)
+		}
+	}
+
 	watcher.Watch()
 
 	return watcher, nil
 }
 
 // SyncWatchJSON synchronize json value of the path to obj, and trigger listener when value change
-func (cli *Client) SyncWatchJSON(path string, obj interface{}, listener ValueListener) (*Watcher, error) {
-	return cli.SyncWatch(path, obj, &JSONCodec{}, listener)
+func (cli *Client) SyncWatchJSON(path string, obj interface{}, listener ValueListener, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatch(path, obj, &JSONCodec{}, listener, options...)
 }
 
 // SyncWatchJSON synchronize string value of the path to obj, and trigger listener when value change
-func (cli *Client) SyncWatchString(path string, s *string, listener ValueListener) (*Watcher, error) {
-	return cli.SyncWatch(path, s, stringCodec, listener)
+func (cli *Client) SyncWatchString(path string, s *string, listener ValueListener, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatch(path, s, stringCodec, listener, options...)
 }
 
 // WatchJSON watch json value of the path to obj, and trigger listener when value change
-func (cli *Client) WatchJSON(path string, obj interface{}, listener ValueListener) (*Watcher, error) {
-	return cli.Watch(path, obj, &JSONCodec{}, listener)
+func (cli *Client) WatchJSON(path string, obj interface{}, listener ValueListener, options ...SyncOption) (*Watcher, error) {
+	return cli.Watch(path, obj, &JSONCodec{}, listener, options...)
 }
 
 // WatchJSON watch string value of the path to obj, and trigger listener when value change
-func (cli *Client) WatchString(path string, s *string, listener ValueListener) (*Watcher, error) {
-	return cli.Watch(path, s, stringCodec, listener)
+func (cli *Client) WatchString(path string, s *string, listener ValueListener, options ...SyncOption) (*Watcher, error) {
+	return cli.Watch(path, s, stringCodec, listener, options...)
 }
 
 // SyncMap synchronize sub-path value into a map
-func (cli *Client) SyncMap(path string, m interface{}, valueCodec Codec, syncChild bool) (*Watcher, error) {
-	return cli.SyncWatchMap(path, m, valueCodec, syncChild, nil)
+func (cli *Client) SyncMap(path string, m interface{}, valueCodec Codec, syncChild bool, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatchMap(path, m, valueCodec, syncChild, nil, options...)
 }
 
 // SyncWatchMap synchronize sub-path value into a map, and trigger listener when child value change
-func (cli *Client) SyncWatchMap(path string, m interface{}, valueCodec Codec, syncChild bool, listener ChildListener) (*Watcher, error) {
-	handler, err := cli.newMapHandler(path, m, syncChild, valueCodec, false, listener)
+func (cli *Client) SyncWatchMap(path string, m interface{}, valueCodec Codec, syncChild bool,
+	listener ChildListener, options ...SyncOption) (*Watcher, error) {
+	handler, err := cli.newMapHandler(path, m, syncChild, valueCodec, false, listener, options)
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +86,9 @@ func (cli *Client) SyncWatchMap(path string, m interface{}, valueCodec Codec, sy
 }
 
 // SyncWatchMap synchronize sub-path value into a map, and trigger listener when child value change
-func (cli *Client) WatchMap(path string, m interface{}, valueCodec Codec, syncChild bool, listener ChildListener) (*Watcher, error) {
-	handler, err := cli.newMapHandler(path, m, syncChild, valueCodec, true, listener)
+func (cli *Client) WatchMap(path string, m interface{}, valueCodec Codec, syncChild bool,
+	listener ChildListener, options ...SyncOption) (*Watcher, error) {
+	handler, err := cli.newMapHandler(path, m, syncChild, valueCodec, true, listener, options)
 	if err != nil {
 		return nil, err
 	}
@@ -88,21 +97,48 @@ func (cli *Client) WatchMap(path string, m interface{}, valueCodec Codec, syncCh
 }
 
 // SyncWatchJSONMap synchronize sub-path json value into a map, and trigger listener when child value change
-func (cli *Client) SyncWatchJSONMap(path string, m interface{}, syncChild bool, listener ChildListener) (*Watcher, error) {
-	return cli.SyncWatchMap(path, m, &JSONCodec{}, syncChild, listener)
+func (cli *Client) SyncWatchJSONMap(path string, m interface{}, syncChild bool,
+	listener ChildListener, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatchMap(path, m, &JSONCodec{}, syncChild, listener, options...)
 }
 
 // SyncWatchStringMap synchronize sub-path string value into a map, and trigger listener when child value change
-func (cli *Client) SyncWatchStringMap(path string, m map[string]string, syncChild bool, listener ChildListener) (*Watcher, error) {
-	return cli.SyncWatchMap(path, m, stringCodec, syncChild, listener)
+func (cli *Client) SyncWatchStringMap(path string, m map[string]string, syncChild bool,
+	listener ChildListener, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatchMap(path, m, stringCodec, syncChild, listener, options...)
 }
 
 // WatchJSONMap watch sub-path json value into a map, and trigger listener when child value change
-func (cli *Client) WatchJSONMap(path string, m interface{}, syncChild bool, listener ChildListener) (*Watcher, error) {
-	return cli.WatchMap(path, m, &JSONCodec{}, syncChild, listener)
+func (cli *Client) WatchJSONMap(path string, m interface{}, syncChild bool,
+	listener ChildListener, options ...SyncOption) (*Watcher, error) {
+	return cli.WatchMap(path, m, &JSONCodec{}, syncChild, listener, options...)
 }
 
 // WatchStringMap watch sub-path string value into a map, and trigger listener when child value change
-func (cli *Client) WatchStringMap(path string, m map[string]string, syncChild bool, listener ChildListener) (*Watcher, error) {
-	return cli.WatchMap(path, m, stringCodec, syncChild, listener)
+func (cli *Client) WatchStringMap(path string, m map[string]string, syncChild bool,
+	listener ChildListener, options ...SyncOption) (*Watcher, error) {
+	return cli.WatchMap(path, m, stringCodec, syncChild, listener, options...)
+}
+
+// SyncSlice synchronize sub-path value into a slice sorted by less, SequenceLess is used if less is nil
+func (cli *Client) SyncSlice(path string, s interface{}, valueCodec Codec, syncChild bool,
+	less ChildLess, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatchSlice(path, s, valueCodec, syncChild, less, nil, options...)
+}
+
+// SyncWatchSlice synchronize sub-path value into a slice sorted by less, and trigger listener when item inserted or removed
+func (cli *Client) SyncWatchSlice(path string, s interface{}, valueCodec Codec, syncChild bool,
+	less ChildLess, listener SliceListener, options ...SyncOption) (*Watcher, error) {
+	handler, err := cli.newSliceHandler(path, s, syncChild, valueCodec, less, listener, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.createWatcher(handler)
+}
+
+// SyncWatchJSONSlice synchronize sub-path json value into a slice sorted by less, and trigger listener when item inserted or removed
+func (cli *Client) SyncWatchJSONSlice(path string, s interface{}, syncChild bool,
+	less ChildLess, listener SliceListener, options ...SyncOption) (*Watcher, error) {
+	return cli.SyncWatchSlice(path, s, &JSONCodec{}, syncChild, less, listener, options...)
 }
@@ -0,0 +1,68 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"time"
+)
+
+// OpWatch operation type of watch handle cycles reported to Tracer
+const OpWatch = "watch"
+
+// Tracer start spans of client operations and watch handle cycles, see WithTracer.
+// The parent span is carried by the ctx of the Context suffixed APIs, e.g. GetRawValueContext.
+type Tracer interface {
+	Start(ctx context.Context, op, path string) (context.Context, Span)
+}
+
+// Span of an operation
+type Span interface {
+	// SetVersion set the node version read, written or checked by the operation
+	SetVersion(version int32)
+
+	// End the span with the error of the operation
+	End(err error)
+}
+
+// operation record of a client operation for metrics, tracing and health checking
+type operation struct {
+	client *Client
+	op     string
+	start  time.Time
+	span   Span
+}
+
+// startOperation start the operation of the path, the returned ctx carries the span
+func (cli *Client) startOperation(ctx context.Context, op, path string) (context.Context, *operation) {
+	o := &operation{client: cli, op: op, start: time.Now()}
+
+	if cli.tracer != nil {
+		ctx, o.span = cli.tracer.Start(ctx, op, path)
+	}
+
+	return ctx, o
+}
+
+// version set the node version of the operation
+func (o *operation) version(version int32) {
+	if o.span != nil {
+		o.span.SetVersion(version)
+	}
+}
+
+// end the operation with the error
+func (o *operation) end(err error) {
+	o.client.succeeded(err)
+
+	if o.client.metrics != nil {
+		o.client.metrics.Operation(o.op, time.Since(o.start), err)
+	}
+
+	if o.span != nil {
+		o.span.End(err)
+	}
+}
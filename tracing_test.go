@@ -0,0 +1,75 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+type spanRecord struct {
+	op, path string
+	parent   string
+	version  int32
+	err      error
+	ended    bool
+}
+
+type tracerRecorder struct {
+	spans []*spanRecord
+}
+
+func (r *tracerRecorder) Start(ctx context.Context, op, path string) (context.Context, Span) {
+	s := &spanRecord{op: op, path: path, version: -1}
+	if parent, ok := ctx.Value(spanKey{}).(string); ok {
+		s.parent = parent
+	}
+
+	r.spans = append(r.spans, s)
+
+	return context.WithValue(ctx, spanKey{}, op), s
+}
+
+func (s *spanRecord) SetVersion(version int32) { s.version = version }
+
+func (s *spanRecord) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+type errorHandler struct {
+	path string
+	err  error
+}
+
+func (h *errorHandler) Path() string { return h.path }
+
+func (h *errorHandler) Handle(*Watcher, *zk.Event) (<-chan zk.Event, error) { return nil, h.err }
+
+func TestTracer(t *testing.T) {
+	r := &tracerRecorder{}
+	cli := &Client{}
+	cli.tracer = r
+
+	ctx := context.WithValue(context.Background(), spanKey{}, "parent")
+	_, o := cli.startOperation(ctx, OpSet, "/a")
+	o.version(3)
+	o.end(errors.New("failed"))
+
+	w := &Watcher{client: cli, handler: &errorHandler{path: "/b", err: zk.ErrNoAuth}}
+	_, err := w.handle(nil)
+	assert.Equal(t, zk.ErrNoAuth, err)
+
+	assert.Len(t, r.spans, 2)
+	assert.Equal(t, &spanRecord{op: OpSet, path: "/a", parent: "parent", version: 3, err: errors.New("failed"), ended: true}, r.spans[0])
+	assert.Equal(t, &spanRecord{op: OpWatch, path: "/b", version: -1, err: zk.ErrNoAuth, ended: true}, r.spans[1])
+}
@@ -0,0 +1,260 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	txVotesNode   = "votes"
+	txOutcomeNode = "outcome"
+)
+
+// Outcome of a two-phase commit transaction
+type Outcome string
+
+const (
+	// OutcomeCommit all participants voted commit
+	OutcomeCommit Outcome = "commit"
+	// OutcomeAbort any participant voted abort, lost its session, or the transaction timed out
+	OutcomeAbort Outcome = "abort"
+)
+
+// Transaction proposed by the coordinator
+type Transaction struct {
+	ID           string   `json:"id"`
+	Participants []string `json:"participants"`
+	Payload      []byte   `json:"payload"`
+	Deadline     int64    `json:"deadline"`
+}
+
+// TwoPhaseCommit two-phase commit recipe.
+// The coordinator creates the transaction node, participants vote by ephemeral child nodes of the `votes` node,
+// and the coordinator decides the outcome node which everyone watches.
+type TwoPhaseCommit struct {
+	client *Client
+	path   string
+}
+
+// NewTwoPhaseCommit create two-phase commit recipe storing transactions under the path
+func (cli *Client) NewTwoPhaseCommit(path string) *TwoPhaseCommit {
+	return &TwoPhaseCommit{client: cli, path: path}
+}
+
+func (t *TwoPhaseCommit) txPath(id string, nodes ...string) string {
+	return PathJoin(append([]string{t.path, id}, nodes...)...)
+}
+
+// Propose create the transaction, wait for votes and decide the outcome.
+// The transaction is aborted if any participant votes abort, loses its session after voting,
+// or not all participants voted within the timeout.
+func (t *TwoPhaseCommit) Propose(ctx context.Context, id string, participants []string,
+	payload []byte, timeout time.Duration) (Outcome, error) {
+	if id == "" || len(participants) == 0 {
+		return OutcomeAbort, errors.New("id and participants required")
+	}
+
+	tx := &Transaction{
+		ID:           id,
+		Participants: participants,
+		Payload:      payload,
+		Deadline:     time.Now().Add(timeout).UnixNano() / int64(time.Millisecond),
+	}
+
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return OutcomeAbort, err
+	}
+
+	if err = t.client.EnsurePathContext(ctx, t.path); err != nil {
+		return OutcomeAbort, err
+	}
+
+	err = t.client.multiContext(ctx,
+		&zk.CreateRequest{Path: t.txPath(id), Data: data, Acl: zk.WorldACL(zk.PermAll)},
+		&zk.CreateRequest{Path: t.txPath(id, txVotesNode), Acl: zk.WorldACL(zk.PermAll)},
+	)
+
+	if err != nil {
+		return OutcomeAbort, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	voted := make(map[string]struct{})
+
+	for {
+		outcome, ch, err := t.collectVotes(id, participants, voted)
+		if err != nil {
+			return t.decide(ctx, id, OutcomeAbort, err)
+		}
+
+		if outcome != "" {
+			return t.decide(ctx, id, outcome, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return t.decide(ctx, id, OutcomeAbort, ctx.Err())
+		case <-t.client.done:
+			return OutcomeAbort, zk.ErrClosing
+		case <-timer.C:
+			t.client.log().Warn("zk transaction timeout", "path", t.txPath(id))
+			return t.decide(ctx, id, OutcomeAbort, nil)
+		case <-ch:
+		}
+	}
+}
+
+// collectVotes check votes, return the outcome if decided, otherwise the chan to watch votes
+func (t *TwoPhaseCommit) collectVotes(id string, participants []string, voted map[string]struct{}) (Outcome, <-chan zk.Event, error) {
+	votesPath := t.txPath(id, txVotesNode)
+
+	children, _, ch, err := t.client.Conn().ChildrenW(votesPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	commits := 0
+
+	for _, participant := range participants {
+		if !containsNode(children, participant) {
+			if _, ok := voted[participant]; ok {
+				t.client.log().Warn("zk transaction participant lost", "path", t.txPath(id), "participant", participant)
+				return OutcomeAbort, nil, nil
+			}
+
+			continue
+		}
+
+		vote, _, err := t.client.Conn().Get(PathJoin(votesPath, participant))
+		if err == zk.ErrNoNode {
+			t.client.log().Warn("zk transaction participant lost", "path", t.txPath(id), "participant", participant)
+			return OutcomeAbort, nil, nil
+		}
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		voted[participant] = nilStruct
+
+		if Outcome(vote) != OutcomeCommit {
+			t.client.log().Info("zk transaction participant voted abort", "path", t.txPath(id), "participant", participant)
+			return OutcomeAbort, nil, nil
+		}
+
+		commits++
+	}
+
+	if commits == len(participants) {
+		return OutcomeCommit, nil, nil
+	}
+
+	return "", ch, nil
+}
+
+// decide write the outcome, the existing outcome is returned if already decided
+func (t *TwoPhaseCommit) decide(ctx context.Context, id string, outcome Outcome, cause error) (Outcome, error) {
+	_, err := t.client.CreateContext(ctx, t.txPath(id, txOutcomeNode), []byte(outcome), 0)
+	if err == zk.ErrNodeExists {
+		data, _, getErr := t.client.Conn().Get(t.txPath(id, txOutcomeNode))
+		if getErr != nil {
+			return OutcomeAbort, getErr
+		}
+
+		return Outcome(data), cause
+	}
+
+	if err != nil {
+		return OutcomeAbort, err
+	}
+
+	t.client.log().Info("zk transaction decided", "path", t.txPath(id), "outcome", outcome)
+
+	return outcome, cause
+}
+
+// Get the transaction
+func (t *TwoPhaseCommit) Get(id string) (*Transaction, error) {
+	data, _, err := t.client.Conn().Get(t.txPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{}
+	if err = json.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// Vote for the transaction, the vote is removed if the session of the participant is lost
+func (t *TwoPhaseCommit) Vote(id, participant string, commit bool) error {
+	return t.vote(context.Background(), id, participant, commit)
+}
+
+func (t *TwoPhaseCommit) vote(ctx context.Context, id, participant string, commit bool) error {
+	vote := OutcomeAbort
+	if commit {
+		vote = OutcomeCommit
+	}
+
+	return t.client.CreateTempRawValueContext(ctx, t.txPath(id, txVotesNode, participant), []byte(vote))
+}
+
+// WaitOutcome wait until the outcome decided or the ctx is done
+func (t *TwoPhaseCommit) WaitOutcome(ctx context.Context, id string) (Outcome, error) {
+	outcomePath := t.txPath(id, txOutcomeNode)
+
+	for {
+		exists, _, ch, err := t.client.Conn().ExistsW(outcomePath)
+		if err != nil {
+			return "", err
+		}
+
+		if exists {
+			data, _, err := t.client.Conn().Get(outcomePath)
+			if err != nil {
+				return "", err
+			}
+
+			return Outcome(data), nil
+		}
+
+		if err = t.client.waitEvent(ctx, ch); err != nil {
+			return "", err
+		}
+	}
+}
+
+// Participate read the transaction, vote by the decision, and wait for the outcome
+func (t *TwoPhaseCommit) Participate(ctx context.Context, id, participant string,
+	decide func(tx *Transaction) bool) (Outcome, error) {
+	tx, err := t.Get(id)
+	if err != nil {
+		return "", err
+	}
+
+	if err = t.vote(ctx, id, participant, decide(tx)); err != nil {
+		return "", err
+	}
+
+	return t.WaitOutcome(ctx, id)
+}
+
+// Remove the transaction nodes
+func (t *TwoPhaseCommit) Remove(id string) error {
+	return t.client.DeleteRecursive(t.txPath(id))
+}
@@ -0,0 +1,88 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoPhaseCommit(t *testing.T) {
+	if !isLocalZKAlive(t) {
+		return
+	}
+
+	tpc := testClient.NewTwoPhaseCommit("/test/twophase")
+	_ = tpc.Remove("commit")
+	_ = tpc.Remove("abort")
+	_ = tpc.Remove("timeout")
+
+	defer func() {
+		_ = tpc.Remove("commit")
+		_ = tpc.Remove("abort")
+		_ = tpc.Remove("timeout")
+	}()
+
+	participants := []string{"a", "b", "c"}
+
+	run := func(id string, votes map[string]bool) (Outcome, []Outcome) {
+		var wg sync.WaitGroup
+
+		outcomes := make([]Outcome, len(participants))
+
+		for i, p := range participants {
+			commit, ok := votes[p]
+			if !ok {
+				continue
+			}
+
+			wg.Add(1)
+
+			go func(i int, p string, commit bool) {
+				defer wg.Done()
+
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+				defer cancel()
+
+				for {
+					if _, err := tpc.Get(id); err == nil {
+						break
+					}
+
+					time.Sleep(time.Millisecond * 10)
+				}
+
+				outcome, err := tpc.Participate(ctx, id, p, func(tx *Transaction) bool {
+					assert.Equal(t, "payload", string(tx.Payload))
+					return commit
+				})
+				assert.Nil(t, err)
+				outcomes[i] = outcome
+			}(i, p, commit)
+		}
+
+		outcome, err := tpc.Propose(context.Background(), id, participants, []byte("payload"), time.Second)
+		assert.Nil(t, err)
+		wg.Wait()
+
+		return outcome, outcomes
+	}
+
+	outcome, outcomes := run("commit", map[string]bool{"a": true, "b": true, "c": true})
+	assert.Equal(t, OutcomeCommit, outcome)
+	assert.Equal(t, []Outcome{OutcomeCommit, OutcomeCommit, OutcomeCommit}, outcomes)
+
+	outcome, outcomes = run("abort", map[string]bool{"a": true, "b": false, "c": true})
+	assert.Equal(t, OutcomeAbort, outcome)
+	assert.Equal(t, []Outcome{OutcomeAbort, OutcomeAbort, OutcomeAbort}, outcomes)
+
+	outcome, _ = run("timeout", map[string]bool{"a": true, "b": true})
+	assert.Equal(t, OutcomeAbort, outcome)
+}
@@ -0,0 +1,83 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// Validator validate decoded value before synchronizing it,
+// the last good value is kept if validation failed.
+type Validator interface {
+	Validate(path string, obj interface{}) error
+}
+
+// ValidatorFunc function validator
+type ValidatorFunc func(path string, obj interface{}) error
+
+// Validate call the function
+func (f ValidatorFunc) Validate(path string, obj interface{}) error {
+	return f(path, obj)
+}
+
+// ErrorHandler handle errors of decoding and validating value
+type ErrorHandler func(path string, err error)
+
+// ValidationError error of invalid value
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value of %s: %v", e.Path, e.Err)
+}
+
+// Unwrap return the validator error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validate the decoded value
+func (o *SyncOptions) validate(path string, obj interface{}) error {
+	if o.validator == nil {
+		return nil
+	}
+
+	if err := o.validator.Validate(path, obj); err != nil {
+		return &ValidationError{Path: path, Err: err}
+	}
+
+	return nil
+}
+
+// reportError log and count the error, and trigger the error handler
+func (o *SyncOptions) reportError(cli *Client, path string, err error) {
+	if err == io.EOF {
+		return // ignore nil data
+	}
+
+	cli.log().Warn("zk failed to parse", "path", path, "error", err)
+
+	if cli.metrics != nil {
+		cli.metrics.DecodeFailure(path)
+	}
+
+	if _, ok := err.(*ValidationError); ok {
+		atomic.AddInt64(&cli.validationFailures, 1)
+	}
+
+	if o.errorHandler != nil {
+		o.errorHandler(path, err)
+	}
+}
+
+// ValidationFailures the count of values rejected by validators
+func (cli *Client) ValidationFailures() int64 {
+	return atomic.LoadInt64(&cli.validationFailures)
+}
@@ -0,0 +1,48 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidator(t *testing.T) {
+	cli := &Client{}
+	u := &user{}
+
+	var reported error
+
+	h, err := cli.newValueHandler("/test/validate_user", u, &JSONCodec{}, false, nil, []SyncOption{
+		WithValidator(ValidatorFunc(func(path string, obj interface{}) error {
+			if obj.(*user).Name == "" {
+				return errors.New("name required")
+			}
+
+			return nil
+		})),
+		WithErrorHandler(func(path string, err error) {
+			reported = err
+		}),
+	})
+	assert.Nil(t, err)
+
+	err = h.Decode(nil, []byte(`{"name":"wongoo", "sex":1}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "wongoo", u.Name)
+
+	err = h.Decode(nil, []byte(`{"sex":0}`))
+	assert.NotNil(t, err)
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, "wongoo", u.Name)
+	assert.Equal(t, 1, u.Sex)
+
+	h.reportError(cli, h.path, err)
+	assert.Equal(t, err, reported)
+	assert.Equal(t, int64(1), cli.ValidationFailures())
+}
@@ -0,0 +1,170 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+const (
+	// schemaVersionMagic prefix of the schema version header, the header ends with a new line
+	schemaVersionMagic = "zkclient:schema:"
+
+	// legacySchemaVersion the schema version of data without version header
+	legacySchemaVersion = 1
+)
+
+// MigrationFunc migrate raw data from one schema version to the next version
+type MigrationFunc func(data []byte) ([]byte, error)
+
+// VersionedCodec store schema version alongside the payload encoded by the inner codec,
+// data of old version will be migrated to current version before decoding.
+type VersionedCodec struct {
+	codec      Codec
+	version    int
+	migrations map[int]MigrationFunc
+	writeBack  bool
+}
+
+// NewVersionedCodec create versioned codec of current schema version
+func NewVersionedCodec(codec Codec, version int) *VersionedCodec {
+	return &VersionedCodec{
+		codec:      codec,
+		version:    version,
+		migrations: make(map[int]MigrationFunc),
+	}
+}
+
+// Migration register migration func from version to version+1
+func (c *VersionedCodec) Migration(from int, f MigrationFunc) *VersionedCodec {
+	c.migrations[from] = f
+	return c
+}
+
+// WriteBack whether to write migrated value back to zookeeper when synchronizing
+func (c *VersionedCodec) WriteBack(writeBack bool) *VersionedCodec {
+	c.writeBack = writeBack
+	return c
+}
+
+// Version current schema version
+func (c *VersionedCodec) Version() int {
+	return c.version
+}
+
+// Unwrap return the inner codec
+func (c *VersionedCodec) Unwrap() Codec {
+	return c.codec
+}
+
+// Encode object with current schema version header
+func (c *VersionedCodec) Encode(obj interface{}) ([]byte, error) {
+	data, err := c.codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeSchemaVersion(c.version, data), nil
+}
+
+// Decode migrate data to current version, then decode it by the inner codec
+func (c *VersionedCodec) Decode(data []byte) (interface{}, error) {
+	_, payload, err := c.migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.codec.Decode(payload)
+}
+
+// Migrate migrate data to current version, return the data with version header and whether it's changed
+func (c *VersionedCodec) Migrate(data []byte) ([]byte, bool, error) {
+	version, payload, err := c.migrate(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if version == c.version {
+		return data, false, nil
+	}
+
+	return encodeSchemaVersion(c.version, payload), true, nil
+}
+
+// migrate return the original version and the migrated payload
+func (c *VersionedCodec) migrate(data []byte) (int, []byte, error) {
+	version, payload, err := decodeSchemaVersion(data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if version > c.version {
+		return 0, nil, fmt.Errorf("schema version %d newer than %d", version, c.version)
+	}
+
+	for v := version; v < c.version; v++ {
+		f, ok := c.migrations[v]
+		if !ok {
+			return 0, nil, fmt.Errorf("no migration from schema version %d", v)
+		}
+
+		if payload, err = f(payload); err != nil {
+			return 0, nil, fmt.Errorf("migrate from schema version %d: %w", v, err)
+		}
+	}
+
+	return version, payload, nil
+}
+
+func encodeSchemaVersion(version int, payload []byte) []byte {
+	header := schemaVersionMagic + strconv.Itoa(version) + "\n"
+	return append([]byte(header), payload...)
+}
+
+func decodeSchemaVersion(data []byte) (int, []byte, error) {
+	if !bytes.HasPrefix(data, []byte(schemaVersionMagic)) {
+		return legacySchemaVersion, data, nil
+	}
+
+	data = data[len(schemaVersionMagic):]
+
+	idx := bytes.IndexByte(data, '\n')
+	if idx < 0 {
+		return 0, nil, errInvalidValue
+	}
+
+	version, err := strconv.Atoi(string(data[:idx]))
+	if err != nil {
+		return 0, nil, errInvalidValue
+	}
+
+	return version, data[idx+1:], nil
+}
+
+// writeBackMigration write the migrated value back to zookeeper if the codec requires,
+// the node version is checked so that concurrent updates won't be overwritten.
+func (cli *Client) writeBackMigration(path string, stat *zk.Stat, data []byte, codec Codec) {
+	c, ok := codec.(*VersionedCodec)
+	if !ok || !c.writeBack || stat == nil {
+		return
+	}
+
+	migrated, changed, err := c.Migrate(data)
+	if err != nil || !changed {
+		return
+	}
+
+	if err = cli.SetRawValueVersion(path, migrated, stat.Version); err != nil {
+		cli.log().Warn("zk failed to write back migrated value", "path", path, "error", err)
+		return
+	}
+
+	cli.log().Info("zk migrated value", "path", path, "schema_version", c.version)
+}
@@ -0,0 +1,65 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkclient
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type versionedUser struct {
+	FullName string
+	Gender   string
+}
+
+func newVersionedUserCodec() *VersionedCodec {
+	c := NewVersionedCodec(&JSONCodec{}, 3).
+		Migration(1, func(data []byte) ([]byte, error) {
+			return bytes.Replace(data, []byte(`"name"`), []byte(`"FullName"`), 1), nil
+		}).
+		Migration(2, func(data []byte) ([]byte, error) {
+			data = bytes.Replace(data, []byte(`"sex":1`), []byte(`"Gender":"male"`), 1)
+			return bytes.Replace(data, []byte(`"sex":0`), []byte(`"Gender":"female"`), 1), nil
+		})
+
+	setJSONCodecType(c, reflect.TypeOf(versionedUser{}))
+
+	return c
+}
+
+func TestVersionedCodec(t *testing.T) {
+	c := newVersionedUserCodec()
+
+	obj, err := c.Decode([]byte(`{"name":"wongoo","sex":1}`))
+	assert.Nil(t, err)
+	assert.Equal(t, &versionedUser{FullName: "wongoo", Gender: "male"}, obj)
+
+	obj, err = c.Decode(encodeSchemaVersion(2, []byte(`{"FullName":"jack","sex":0}`)))
+	assert.Nil(t, err)
+	assert.Equal(t, &versionedUser{FullName: "jack", Gender: "female"}, obj)
+
+	data, err := c.Encode(obj)
+	assert.Nil(t, err)
+
+	version, _, err := decodeSchemaVersion(data)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, version)
+
+	_, changed, err := c.Migrate(data)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+
+	migrated, changed, err := c.Migrate([]byte(`{"name":"yang","sex":0}`))
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, encodeSchemaVersion(3, []byte(`{"FullName":"yang","Gender":"female"}`)), migrated)
+
+	_, err = c.Decode(encodeSchemaVersion(4, []byte(`{}`)))
+	assert.NotNil(t, err)
+}
@@ -6,12 +6,13 @@
 package zkclient
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/samuel/go-zookeeper/zk"
-	"github.com/vogo/logger"
 )
 
 // EventHandler zookeeper event listener
@@ -32,13 +33,30 @@ type ChildListener interface {
 	Delete(path, child string)
 }
 
+// ValueChangeListener node watch listener receiving both the previous and the new value,
+// the previous stat and value are nil for the first update.
+type ValueChangeListener interface {
+	Change(path string, oldStat, newStat *zk.Stat, oldObj, newObj interface{})
+	Remove(path string, lastStat *zk.Stat, lastObj interface{})
+}
+
+// ChildChangeListener child watch listener receiving both the previous and the new value,
+// the previous stat and value are nil for a new child.
+type ChildChangeListener interface {
+	Change(path, child string, oldStat, newStat *zk.Stat, oldObj, newObj interface{})
+	Remove(path, child string, lastStat *zk.Stat, lastObj interface{})
+}
+
 // Watcher zookeeper watcher
 type Watcher struct {
 	sync.Mutex
-	client  *Client
-	handler EventHandler
-	done    chan struct{}
-	alive   int32
+	client      *Client
+	handler     EventHandler
+	done        chan struct{}
+	alive       int32
+	lastHandled time.Time
+	lastErr     error
+	lastErrTime time.Time
 }
 
 // NewWatcher create new watcher
@@ -85,10 +103,16 @@ func (w *Watcher) Close() {
 func (w *Watcher) Watch() {
 	go func() {
 		path := w.handler.Path()
-		logger.Debugf("zk watcher [%s] start", path)
+		log := w.client.log()
+		log.Debug("zk watcher start", "path", path, "session", w.client.SessionID())
 
 		atomic.StoreInt32(&w.alive, 1)
-		defer atomic.StoreInt32(&w.alive, 0)
+		w.client.register(w)
+
+		defer func() {
+			atomic.StoreInt32(&w.alive, 0)
+			w.client.unregister(w)
+		}()
 
 		var (
 			evt *zk.Event
@@ -97,9 +121,9 @@ func (w *Watcher) Watch() {
 		)
 
 		for {
-			ch, err = w.handler.Handle(w, evt)
+			ch, err = w.handle(evt)
 			if err != nil {
-				logger.Errorf("zk watcher [%s] handle error: %v", path, err)
+				log.Error("zk watcher handle error", "path", path, "session", w.client.SessionID(), "error", err)
 
 				if IsZKRecoverableErr(err) {
 					w.client.AppendDeadWatcher(w)
@@ -109,7 +133,7 @@ func (w *Watcher) Watch() {
 			}
 
 			if ch == nil {
-				logger.Debugf("zk watcher [%s] exit", path)
+				log.Debug("zk watcher exit", "path", path)
 
 				// return nil chan to exit watcher
 				return
@@ -117,16 +141,17 @@ func (w *Watcher) Watch() {
 
 			select {
 			case <-w.client.done:
-				logger.Debugf("zk watcher [%s] exit for client closed", path)
+				log.Debug("zk watcher exit for client closed", "path", path)
 				w.Close()
 
 				return
 			case <-w.done:
-				logger.Debugf("zk watcher [%s] exit for watcher closed", path)
+				log.Debug("zk watcher exit for watcher closed", "path", path)
 				return
 			case event := <-ch:
 				evt = &event
-				logger.Debugf("zk watcher [%s] new event: %v", path, evt)
+				log.Debug("zk watcher new event", "path", path, "session", w.client.SessionID(),
+					"type", evt.Type, "state", evt.State)
 
 				if !StateAlive(evt.State) {
 					w.client.AppendDeadWatcher(w)
@@ -137,6 +162,42 @@ func (w *Watcher) Watch() {
 	}()
 }
 
+// handle the event by the handler, traced as a watch handle cycle
+func (w *Watcher) handle(evt *zk.Event) (<-chan zk.Event, error) {
+	var span Span
+	if w.client.tracer != nil {
+		_, span = w.client.tracer.Start(context.Background(), OpWatch, w.handler.Path())
+	}
+
+	ch, err := w.handler.Handle(w, evt)
+
+	if span != nil {
+		span.End(err)
+	}
+
+	w.record(err)
+
+	return ch, err
+}
+
+// record the result of the handle cycle for health checking
+func (w *Watcher) record(err error) {
+	now := time.Now()
+
+	w.Lock()
+
+	if err != nil {
+		w.lastErr = err
+		w.lastErrTime = now
+	} else {
+		w.lastHandled = now
+	}
+
+	w.Unlock()
+
+	w.client.succeeded(err)
+}
+
 func (w *Watcher) newChildWatcher(handler EventHandler) *Watcher {
 	return &Watcher{
 		client:  w.client,
@@ -144,3 +205,15 @@ func (w *Watcher) newChildWatcher(handler EventHandler) *Watcher {
 		done:    w.done,
 	}
 }
+
+// waitEvent wait for the watch event, the client closed or the ctx done
+func (cli *Client) waitEvent(ctx context.Context, ch <-chan zk.Event) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cli.done:
+		return zk.ErrClosing
+	case <-ch:
+		return nil
+	}
+}
@@ -6,13 +6,12 @@
 package zkclient
 
 import (
-	"fmt"
+	"context"
 	"net"
 	"sync"
 	"time"
 
 	"github.com/samuel/go-zookeeper/zk"
-	"github.com/vogo/logger"
 )
 
 const (
@@ -24,11 +23,15 @@ type AlarmTrigger func(err error)
 
 // Client for zookeeper
 type Client struct {
+	validationFailures int64 // keep 64-bit aligned for atomic operations
+	lastSuccess        int64
+	sessions           int32
 	sync.Mutex
 	ClientOptions
 	servers      []string
 	conn         *zk.Conn
 	done         chan struct{}
+	watchers     map[*Watcher]struct{}
 	deadWatchers []*Watcher
 	dialer       zk.Dialer
 }
@@ -89,10 +92,11 @@ func (cli *Client) startConnMaintainer() {
 // collectDeadWatchers return queued watchers, and empty the queue
 func (cli *Client) collectDeadWatchers() []*Watcher {
 	cli.Lock()
-	defer cli.Unlock()
-
 	watchers := cli.deadWatchers
 	cli.deadWatchers = []*Watcher{}
+	cli.Unlock()
+
+	cli.reportWatchers()
 
 	return watchers
 }
@@ -100,38 +104,60 @@ func (cli *Client) collectDeadWatchers() []*Watcher {
 // AppendDeadWatcher add dead watcher, wait to watch again
 func (cli *Client) AppendDeadWatcher(watcher *Watcher) {
 	cli.Lock()
-	defer cli.Unlock()
-
-	logger.Debugf("zk watcher append to dead queue: %s", watcher.handler.Path())
+	cli.log().Debug("zk watcher append to dead queue", "path", watcher.handler.Path())
 	watcher.client = cli
 	cli.deadWatchers = append(cli.deadWatchers, watcher)
-}
-
-// zkLogger logger for zookeeper
-type zkLogger struct {
-}
-
-// Printf only write log when debug level enabled
-func (l *zkLogger) Printf(format string, a ...interface{}) {
-	if logger.Level < logger.LevelDebug {
-		return
-	}
+	cli.Unlock()
 
-	logger.WriteLog("ZOOK", fmt.Sprintf(format, a...))
+	cli.reportWatchers()
 }
 
 // Close connection
 func (cli *Client) connect() error {
-	conn, _, err := zk.Connect(cli.servers, cli.timeout, zk.WithLogger(&zkLogger{}), zk.WithDialer(cli.dialer))
+	var connLog zk.Logger = &zkLogger{}
+
+	if cli.logger != nil {
+		connLog = &connLogger{logger: cli.logger}
+	}
+
+	conn, _, err := zk.Connect(cli.servers, cli.timeout, zk.WithLogger(connLog), zk.WithDialer(cli.dialer),
+		zk.WithEventCallback(cli.onEvent))
 	cli.conn = conn
 
 	if err != nil {
 		return err
 	}
 
+	if l, ok := connLog.(*connLogger); ok {
+		l.conn.Store(conn)
+	}
+
+	for _, a := range cli.auths {
+		if err := cli.addAuth(conn, a); err != nil {
+			cli.log().Warn("zk add auth error", "scheme", a.scheme, "error", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
+// addAuth add auth to the connection, the request is queued until connected, so wait it at most the timeout
+func (cli *Client) addAuth(conn *zk.Conn, a clientAuth) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- conn.AddAuth(a.scheme, a.auth)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(cli.timeout):
+		return zk.ErrNoServer
+	}
+}
+
 // Close client, NOT use Client which already calling Close()
 func (cli *Client) Close() {
 	cli.Lock()
@@ -176,40 +202,131 @@ func (cli *Client) Conn() *zk.Conn {
 
 // EnsurePath check or create target path
 func (cli *Client) EnsurePath(path string) error {
+	return cli.EnsurePathContext(context.Background(), path)
+}
+
+// EnsurePathContext check or create target path, the operation is traced as a child span of the ctx
+func (cli *Client) EnsurePathContext(ctx context.Context, path string) (err error) {
+	_, o := cli.startOperation(ctx, OpEnsurePath, path)
+	defer func() { o.end(err) }()
+
+	created, err := cli.createPath(path)
+	if created || err != nil {
+		cli.audit(ctx, OpEnsurePath, path, noVersion, 0, nil, err)
+	}
+
+	return err
+}
+
+func (cli *Client) ensurePath(path string) error {
+	_, err := cli.createPath(path)
+	return err
+}
+
+// createPath create the path and its parents if not exist, return whether the path is created
+func (cli *Client) createPath(path string) (bool, error) {
 	exists, _, err := cli.conn.Exists(path)
-	if err != nil {
-		return err
+	if err != nil || exists {
+		return false, err
 	}
 
-	if !exists {
-		_, err := cli.conn.Create(path, []byte(""), 0, zk.WorldACL(zk.PermAll))
-		if err != nil {
-			if err != zk.ErrNoNode {
-				return err
-			}
+	if err = cli.checkCreate(path); err != nil {
+		return false, err
+	}
 
-			// create parent
-			if err := cli.EnsurePath(ParentNode(path)); err != nil {
-				return err
-			}
+	_, err = cli.conn.Create(path, []byte(""), 0, zk.WorldACL(zk.PermAll))
+	if err == nil {
+		return true, nil
+	}
 
-			// create again
-			if _, err := cli.conn.Create(path, []byte(""), 0, zk.WorldACL(zk.PermAll)); err != nil {
-				return err
-			}
+	if err == zk.ErrNodeExists {
+		// created by others concurrently
+		return false, nil
+	}
+
+	if err != zk.ErrNoNode {
+		return false, err
+	}
+
+	// create parent
+	if err = cli.ensurePath(ParentNode(path)); err != nil {
+		return false, err
+	}
+
+	// create again
+	if _, err = cli.conn.Create(path, []byte(""), 0, zk.WorldACL(zk.PermAll)); err != nil {
+		if err == zk.ErrNodeExists {
+			return false, nil
 		}
+
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
 // Delete path
 func (cli *Client) Delete(path string) error {
-	logger.Debugf("zk delete node [%s]", path)
+	return cli.DeleteContext(context.Background(), path)
+}
+
+// DeleteContext delete path, the operation is traced as a child span of the ctx
+func (cli *Client) DeleteContext(ctx context.Context, path string) (err error) {
+	cli.log().Debug("zk delete node", "path", path)
+
+	_, o := cli.startOperation(ctx, OpDelete, path)
+	defer func() { o.end(err) }()
+
+	oldVersion := noVersion
 
-	if err := cli.conn.Delete(path, -1); err != nil && err != zk.ErrNoNode {
+	if err = cli.checkDelete(path, false); err != nil {
+		cli.audit(ctx, OpDelete, path, oldVersion, noVersion, nil, err)
+		return err
+	}
+
+	if cli.auditing() {
+		if exists, stat, existsErr := cli.conn.Exists(path); existsErr == nil && exists {
+			oldVersion = stat.Version
+		}
+	}
+
+	err = cli.conn.Delete(path, -1)
+	if err == zk.ErrNotEmpty {
+		err = cli.deleteChunkedValue(path)
+	}
+
+	if err != zk.ErrNoNode {
+		cli.audit(ctx, OpDelete, path, oldVersion, noVersion, nil, err)
+	}
+
+	if err != nil && err != zk.ErrNoNode {
 		return err
 	}
 
 	return nil
 }
+
+// DeleteRecursive delete path and all its children
+func (cli *Client) DeleteRecursive(path string) error {
+	if err := cli.checkDelete(path, true); err != nil {
+		cli.audit(context.Background(), OpDelete, path, noVersion, noVersion, nil, err)
+		return err
+	}
+
+	children, _, err := cli.conn.Children(path)
+	if err == zk.ErrNoNode {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if err := cli.DeleteRecursive(PathJoin(path, child)); err != nil {
+			return err
+		}
+	}
+
+	return cli.Delete(path)
+}
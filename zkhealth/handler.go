@@ -0,0 +1,177 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+// Package zkhealth report health of zkclient over http, for readiness and liveness probes.
+// The response is a json report, with status 200 if healthy, otherwise 503.
+//
+//	http.Handle("/healthz", zkhealth.NewHandler(client))
+//	http.Handle("/readyz", zkhealth.NewHandler(client, zkhealth.WithNoDeadWatchers()))
+package zkhealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vogo/zkclient"
+)
+
+// Option option of handler
+type Option func(*Handler)
+
+// WithNoDeadWatchers report unhealthy if any watcher is queued to watch again
+func WithNoDeadWatchers() Option {
+	return func(h *Handler) {
+		h.noDeadWatchers = true
+	}
+}
+
+// WithMaxIdle report unhealthy if no operation or watch succeeded within the duration
+func WithMaxIdle(d time.Duration) Option {
+	return func(h *Handler) {
+		h.maxIdle = d
+	}
+}
+
+// WithoutPaths not report the status of each watched path
+func WithoutPaths() Option {
+	return func(h *Handler) {
+		h.withoutPaths = true
+	}
+}
+
+// Handler http handler reporting health of the client
+type Handler struct {
+	client         *zkclient.Client
+	noDeadWatchers bool
+	maxIdle        time.Duration
+	withoutPaths   bool
+}
+
+// NewHandler create handler reporting health of the client,
+// which is healthy if the connection is alive by default.
+func NewHandler(client *zkclient.Client, options ...Option) *Handler {
+	h := &Handler{client: client}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	return h
+}
+
+// Report health report
+type Report struct {
+	Healthy          bool           `json:"healthy"`
+	Reasons          []string       `json:"reasons,omitempty"`
+	State            string         `json:"state"`
+	SessionID        string         `json:"session_id"`
+	LastSuccess      string         `json:"last_success,omitempty"`
+	SinceLastSuccess string         `json:"since_last_success,omitempty"`
+	Watchers         WatcherSummary `json:"watchers"`
+	Paths            []PathStatus   `json:"paths,omitempty"`
+}
+
+// WatcherSummary count of alive watchers, and dead watchers queued to watch again
+type WatcherSummary struct {
+	Alive int `json:"alive"`
+	Dead  int `json:"dead"`
+}
+
+// PathStatus status of a watched path
+type PathStatus struct {
+	Path          string `json:"path"`
+	Alive         bool   `json:"alive"`
+	LastHandled   string `json:"last_handled,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastErrorTime string `json:"last_error_time,omitempty"`
+}
+
+// Report check the health of the client
+func (h *Handler) Report() *Report {
+	now := time.Now()
+	state := h.client.State()
+
+	r := &Report{
+		Healthy:   true,
+		State:     state.String(),
+		SessionID: fmt.Sprintf("0x%x", h.client.SessionID()),
+	}
+
+	if !zkclient.StateAlive(state) {
+		r.unhealthy("connection not alive")
+	}
+
+	if last := h.client.LastSuccess(); !last.IsZero() {
+		idle := now.Sub(last)
+		r.LastSuccess = formatTime(last)
+		r.SinceLastSuccess = idle.Round(time.Millisecond).String()
+
+		if h.maxIdle > 0 && idle > h.maxIdle {
+			r.unhealthy("no success within " + h.maxIdle.String())
+		}
+	} else if h.maxIdle > 0 {
+		r.unhealthy("no success yet")
+	}
+
+	r.Watchers.Alive, r.Watchers.Dead = h.client.WatcherCount()
+
+	if h.noDeadWatchers && r.Watchers.Dead > 0 {
+		r.unhealthy(fmt.Sprintf("%d dead watchers", r.Watchers.Dead))
+	}
+
+	if !h.withoutPaths {
+		for _, status := range h.client.WatcherStatuses() {
+			r.Paths = append(r.Paths, newPathStatus(status))
+		}
+	}
+
+	return r
+}
+
+func (r *Report) unhealthy(reason string) {
+	r.Healthy = false
+	r.Reasons = append(r.Reasons, reason)
+}
+
+func newPathStatus(status zkclient.WatcherStatus) PathStatus {
+	p := PathStatus{
+		Path:        status.Path,
+		Alive:       status.Alive,
+		LastHandled: formatTime(status.LastHandled),
+	}
+
+	if status.LastError != nil {
+		p.LastError = status.LastError.Error()
+		p.LastErrorTime = formatTime(status.LastErrorTime)
+	}
+
+	return p
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r := h.Report()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(r)
+}
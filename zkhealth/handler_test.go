@@ -0,0 +1,47 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkhealth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/zkclient"
+)
+
+func TestHandler(t *testing.T) {
+	// no server listening, the connection is never alive
+	client := zkclient.NewClient([]string{"127.0.0.1:1"}, zkclient.WithTimeout(time.Second))
+	defer client.Close()
+
+	rec := httptest.NewRecorder()
+	NewHandler(client, WithMaxIdle(time.Minute)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	r := &Report{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), r))
+	assert.False(t, r.Healthy)
+	assert.Equal(t, []string{"connection not alive", "no success yet"}, r.Reasons)
+	assert.Equal(t, "0x0", r.SessionID)
+	assert.Empty(t, r.Paths)
+}
+
+func TestPathStatus(t *testing.T) {
+	now := time.Now()
+
+	p := newPathStatus(zkclient.WatcherStatus{Path: "/a", Alive: true, LastHandled: now})
+	assert.Equal(t, PathStatus{Path: "/a", Alive: true, LastHandled: now.Format(time.RFC3339Nano)}, p)
+
+	p = newPathStatus(zkclient.WatcherStatus{Path: "/a", LastError: errors.New("failed"), LastErrorTime: now})
+	assert.Equal(t, PathStatus{Path: "/a", LastError: "failed", LastErrorTime: now.Format(time.RFC3339Nano)}, p)
+}
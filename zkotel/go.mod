@@ -0,0 +1,16 @@
+module github.com/vogo/zkclient/zkotel
+
+go 1.13
+
+require (
+	github.com/samuel/go-zookeeper v0.0.0-20190923202752-2cc03de413da
+	github.com/stretchr/testify v1.7.0
+	github.com/vogo/zkclient v0.0.0-20261019021651-0daf3efc0d6a
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
+)
+
+// the replace is for local development only and ignored by dependents,
+// release the root module first, then require its version here before tagging this module.
+replace github.com/vogo/zkclient => ../
@@ -0,0 +1,76 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+// Package zkotel trace zkclient operations by OpenTelemetry,
+// it is a separate module so that the client does not depend on OpenTelemetry.
+//
+//	client := zkclient.NewClient(servers, zkclient.WithTracer(zkotel.NewTracer(otel.GetTracerProvider())))
+//	data, stat, err := client.GetRawValueContext(ctx, path)
+package zkotel
+
+import (
+	"context"
+
+	"github.com/vogo/zkclient"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName name of the tracer
+const instrumentationName = "github.com/vogo/zkclient"
+
+// span attribute keys
+const (
+	AttrOp      = attribute.Key("zk.op")
+	AttrPath    = attribute.Key("zk.path")
+	AttrVersion = attribute.Key("zk.version")
+)
+
+// Tracer implements zkclient.Tracer by an OpenTelemetry tracer
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ zkclient.Tracer = (*Tracer)(nil)
+
+// NewTracer create tracer from the tracer provider
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: provider.Tracer(instrumentationName)}
+}
+
+// Start implements zkclient.Tracer, the span is named as "zk.<op>"
+func (t *Tracer) Start(ctx context.Context, op, path string) (context.Context, zkclient.Span) {
+	ctx, span := t.tracer.Start(ctx, "zk."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "zookeeper"),
+			AttrOp.String(op),
+			AttrPath.String(path),
+		),
+	)
+
+	return ctx, &Span{span: span}
+}
+
+// Span implements zkclient.Span by an OpenTelemetry span
+type Span struct {
+	span trace.Span
+}
+
+// SetVersion implements zkclient.Span
+func (s *Span) SetVersion(version int32) {
+	s.span.SetAttributes(AttrVersion.Int64(int64(version)))
+}
+
+// End implements zkclient.Span
+func (s *Span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,49 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/zkclient"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := NewTracer(provider)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	_, span := tracer.Start(ctx, zkclient.OpSet, "/a")
+	span.SetVersion(3)
+	span.End(zk.ErrBadVersion)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+
+	s := spans[0]
+	assert.Equal(t, "zk.set", s.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+	assert.Equal(t, codes.Error, s.Status().Code)
+	assert.Len(t, s.Events(), 1)
+
+	attrs := attribute.NewSet(s.Attributes()...)
+	op, _ := attrs.Value(AttrOp)
+	path, _ := attrs.Value(AttrPath)
+	version, _ := attrs.Value(AttrVersion)
+	assert.Equal(t, zkclient.OpSet, op.AsString())
+	assert.Equal(t, "/a", path.AsString())
+	assert.Equal(t, int64(3), version.AsInt64())
+}
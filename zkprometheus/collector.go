@@ -0,0 +1,151 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+// Package zkprometheus export zkclient metrics to prometheus,
+// it is a separate module so that the client does not depend on prometheus.
+//
+//	collector := zkprometheus.NewCollector("myapp", nil)
+//	prometheus.MustRegister(collector)
+//	client := zkclient.NewClient(servers, zkclient.WithMetrics(collector))
+package zkprometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/vogo/zkclient"
+)
+
+const subsystem = "zkclient"
+
+// operation results
+const (
+	resultOK     = "ok"
+	resultNoNode = "no_node"
+	resultError  = "error"
+)
+
+// Collector prometheus collector implementing zkclient.Metrics
+type Collector struct {
+	reconnects       prometheus.Counter
+	connected        prometheus.Gauge
+	state            prometheus.Gauge
+	operations       *prometheus.HistogramVec
+	watchers         *prometheus.GaugeVec
+	decodeFailures   *prometheus.CounterVec
+	listenerDuration *prometheus.HistogramVec
+}
+
+var _ zkclient.Metrics = (*Collector)(nil)
+
+// NewCollector create collector of metrics with the namespace and const labels
+func NewCollector(namespace string, constLabels prometheus.Labels) *Collector {
+	return &Collector{
+		reconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name: "reconnects_total",
+			Help: "Count of sessions re-established after lost.",
+		}),
+		connected: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name: "connected",
+			Help: "Whether the connection is alive, 1 for alive.",
+		}),
+		state: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name: "connection_state",
+			Help: "Current zookeeper connection state code.",
+		}),
+		operations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name:    "operation_duration_seconds",
+			Help:    "Latency of zookeeper operations by op type and result.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"op", "result"}),
+		watchers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name: "watchers",
+			Help: "Count of watchers by status, dead watchers are queued to watch again.",
+		}, []string{"status"}),
+		decodeFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name: "decode_failures_total",
+			Help: "Count of values failed to decode or validate by path.",
+		}, []string{"path"}),
+		listenerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Subsystem: subsystem, ConstLabels: constLabels,
+			Name:    "listener_duration_seconds",
+			Help:    "Time used by listeners by synchronized path.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"path"}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.reconnects, c.connected, c.state, c.operations, c.watchers, c.decodeFailures, c.listenerDuration,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// Reconnected implements zkclient.Metrics
+func (c *Collector) Reconnected() {
+	c.reconnects.Inc()
+}
+
+// ConnectionState implements zkclient.Metrics
+func (c *Collector) ConnectionState(state zk.State) {
+	c.state.Set(float64(state))
+
+	if zkclient.StateAlive(state) {
+		c.connected.Set(1)
+	} else {
+		c.connected.Set(0)
+	}
+}
+
+// Operation implements zkclient.Metrics
+func (c *Collector) Operation(op string, duration time.Duration, err error) {
+	result := resultOK
+
+	switch {
+	case err == zk.ErrNoNode:
+		result = resultNoNode
+	case err != nil:
+		result = resultError
+	}
+
+	c.operations.WithLabelValues(op, result).Observe(duration.Seconds())
+}
+
+// Watchers implements zkclient.Metrics
+func (c *Collector) Watchers(alive, dead int) {
+	c.watchers.WithLabelValues("alive").Set(float64(alive))
+	c.watchers.WithLabelValues("dead").Set(float64(dead))
+}
+
+// DecodeFailure implements zkclient.Metrics
+func (c *Collector) DecodeFailure(path string) {
+	c.decodeFailures.WithLabelValues(path).Inc()
+}
+
+// ListenerLatency implements zkclient.Metrics
+func (c *Collector) ListenerLatency(path string, duration time.Duration) {
+	c.listenerDuration.WithLabelValues(path).Observe(duration.Seconds())
+}
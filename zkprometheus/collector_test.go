@@ -0,0 +1,49 @@
+// Copyright 2018-2019 The vogo Authors. All rights reserved.
+// author: wongoo
+// since: 2026/10/19
+//
+
+package zkprometheus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samuel/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+	"github.com/vogo/zkclient"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("test", nil)
+
+	registry := prometheus.NewPedanticRegistry()
+	assert.Nil(t, registry.Register(c))
+
+	c.Reconnected()
+	c.ConnectionState(zk.StateHasSession)
+	c.Operation(zkclient.OpGet, time.Millisecond, nil)
+	c.Operation(zkclient.OpGet, time.Millisecond, zk.ErrNoNode)
+	c.Operation(zkclient.OpSet, time.Millisecond, errors.New("failed"))
+	c.Watchers(3, 1)
+	c.DecodeFailure("/a")
+	c.DecodeFailure("/a")
+	c.ListenerLatency("/a", time.Millisecond)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.reconnects))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.connected))
+	assert.Equal(t, float64(3), testutil.ToFloat64(c.watchers.WithLabelValues("alive")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.watchers.WithLabelValues("dead")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.decodeFailures.WithLabelValues("/a")))
+	assert.Equal(t, 3, testutil.CollectAndCount(c.operations))
+
+	c.ConnectionState(zk.StateDisconnected)
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.connected))
+
+	count, err := testutil.GatherAndCount(registry)
+	assert.Nil(t, err)
+	assert.True(t, count > 0)
+}
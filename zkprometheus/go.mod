@@ -0,0 +1,14 @@
+module github.com/vogo/zkclient/zkprometheus
+
+go 1.13
+
+require (
+	github.com/prometheus/client_golang v1.11.1
+	github.com/samuel/go-zookeeper v0.0.0-20190923202752-2cc03de413da
+	github.com/stretchr/testify v1.7.0
+	github.com/vogo/zkclient v0.0.0-20261019021651-0daf3efc0d6a
+)
+
+// the replace is for local development only and ignored by dependents,
+// release the root module first, then require its version here before tagging this module.
+replace github.com/vogo/zkclient => ../